@@ -0,0 +1,58 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+import (
+	v2 "github.com/szabba/assert/v2"
+)
+
+// An Asserter is used to make assertions.
+//
+// It is the Asserter of v2, so v1 code can chain assertions and use the reusable assertions of v2 with it:
+//
+//	assert.Using(t.Errorf).
+//		That(theval.Equal(got.ID, 7)).
+//		That(theslice.Length(got.Items, 2))
+type Asserter = v2.Asserter
+
+// Using creates an Asserter that uses onErr to report failures.
+func Using(onErr ErrorFunc) Asserter {
+	return v2.Using(onErr.V2())
+}
+
+// UsingPanic creates an Asserter that panics to report failures.
+func UsingPanic() Asserter {
+	return v2.UsingPanic()
+}
+
+// V2 converts f to the ErrorFunc of v2.
+//
+// A nil f becomes a nil v2.ErrorFunc, so an Asserter using it panics to report failures.
+func (f ErrorFunc) V2() v2.ErrorFunc {
+	return v2.ErrorFunc(f)
+}
+
+// FromV2 converts the ErrorFunc of v2 to the one of v1.
+func FromV2(f v2.ErrorFunc) ErrorFunc {
+	return ErrorFunc(f)
+}
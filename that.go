@@ -26,8 +26,14 @@ import (
 	v2 "github.com/szabba/assert/v2"
 )
 
+// An ErrorFunc describes what to do when an assertion fails.
+//
+// It has the same shape as the ErrorFunc of v2 and converts to it with V2.
 type ErrorFunc func(msgFmt string, args ...interface{})
 
+// That asserts cond is true, reporting a failure with onError.
+//
+// New code should prefer Using, which allows chaining assertions.
 func That(cond bool, onError ErrorFunc, msgFmt string, args ...interface{}) {
 	v2.
 		Using(v2.ErrorFunc(onError)).
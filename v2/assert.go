@@ -22,43 +22,74 @@
 
 package assert
 
-import "fmt"
-
 // UsingPanic creates an Asserter that panics to report failures.
+//
+// The panic value is an *AssertionError.
 func UsingPanic() Asserter {
 	return Using(nil)
 }
 
 // Using creates an Asserter uses onErr to report failures.
 func Using(onErr ErrorFunc) Asserter {
-	return Asserter{onErr}
+	return Asserter{onErr: onErr}
 }
 
 // An ErrorFunc describes what to do when an assertion fails.
 type ErrorFunc func(msgFmt string, args ...any)
 
 // An Asserter is used to make assertions.
-type Asserter struct{ onErr ErrorFunc }
+type Asserter struct {
+	onErr ErrorFunc
+
+	metrics *Metrics
+	site    string
+}
 
 // That asserts cond is true.
 //
 // The error func of the asserter receives msgFmt and args as input.
-// If the asserter has a nil error func, That panics with a message formatted by fmt.Sprintf.
+// When there are no args, but msgFmt contains a verb other than %%, it cannot be meant as a format.
+// That happens when the message of a reusable assertion includes a value with a % in it,
+// so the error func receives "%s" and msgFmt instead, and the message is reported unchanged.
+// If the asserter has a nil error func, That panics with an *AssertionError holding the message formatted by fmt.Sprintf.
 //
 // When the assertion passes, the same asserter is returned.
 // This enables chaining multiple assertions that share and error func.
 func (a Asserter) That(cond bool, msgFmt string, args ...any) Asserter {
-	if !cond {
+	if a.report(cond) {
 		a.fail(msgFmt, args)
 	}
 	return a
 }
 
+// report records the outcome of an assertion in the metrics of the asserter and tells whether it should be reported.
+//
+// It must be called directly by the exported methods of Asserter, so it can find the assertion site.
+func (a Asserter) report(ok bool) bool {
+	if a.metrics == nil {
+		return !ok
+	}
+	return a.metrics.record(a.site, callerPC(), ok)
+}
+
 func (a Asserter) fail(msgFmt string, args []any) {
-	if a.onErr == nil {
-		msg := fmt.Sprintf(msgFmt, args...)
-		panic(msg)
+	if len(args) == 0 && hasVerb(msgFmt) {
+		msgFmt, args = "%s", []any{msgFmt}
 	}
+	onErr := hooked(a.errorFunc())
+	onErr(msgFmt, args...)
+}
 
-	a.onErr(msgFmt, args...)
+// hasVerb tells whether msgFmt contains a formatting verb, not counting %%.
+func hasVerb(msgFmt string) bool {
+	for i := 0; i < len(msgFmt); i++ {
+		if msgFmt[i] != '%' {
+			continue
+		}
+		if i+1 == len(msgFmt) || msgFmt[i+1] != '%' {
+			return true
+		}
+		i++
+	}
+	return false
 }
@@ -23,6 +23,7 @@
 package assert_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/szabba/assert/v2"
@@ -46,8 +47,8 @@ func TestFailingAssertionWithNilErrorFuncPanicsWithTheFormattedMessage(t *testin
 
 	// then
 	wantMsg := "Oops: false"
-	if p != wantMsg {
-		t.Errorf("got panic %#v, not %q", p, wantMsg)
+	if msg := panicMessage(p); msg != wantMsg {
+		t.Errorf("got panic message %q, not %q", msg, wantMsg)
 	}
 }
 
@@ -96,6 +97,38 @@ func TestFailingAssertionCallsNonNilErrorFunc(t *testing.T) {
 	}
 }
 
+func TestFailingAssertionReportsMessagesWithoutArgs(t *testing.T) {
+	for _, tt := range []struct {
+		msg, want string
+	}{
+		{"plain", "plain"},
+		{"100%% done", "100% done"},
+		{`got "100% broken"`, `got "100% broken"`},
+		{"got %d", "got %d"},
+	} {
+		// given
+		var got string
+		errFunc := func(msgFmt string, args ...any) { got = fmt.Sprintf(msgFmt, args...) }
+
+		// when
+		assert.Using(errFunc).That(false, tt.msg)
+
+		// then
+		if got != tt.want {
+			t.Errorf("got message %q for %q, not %q", got, tt.msg, tt.want)
+		}
+	}
+}
+
+// panicMessage returns the message of p, which should be an *assert.AssertionError.
+func panicMessage(p any) string {
+	err, ok := p.(*assert.AssertionError)
+	if !ok {
+		return fmt.Sprintf("panic %#v is not an *assert.AssertionError", p)
+	}
+	return err.Message
+}
+
 func catchPanic(f func()) (caught any) {
 	defer func() { caught = recover() }()
 	f()
@@ -0,0 +1,297 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package assertcheck defines an analyzer that reports misuse of assertions.
+//
+// # Analyzer assertcheck
+//
+// assertcheck: check calls to Asserter.That and ErrorFuncs, and the shape of reusable assertions
+//
+// Calls to Asserter.That, Invariant, contract.Requires and ErrorFuncs are checked like calls to fmt.Printf.
+// The number of arguments has to match the verbs in the format string,
+// and the format string should be a constant, so that it cannot contain verbs by accident.
+// Arguments of type slog.Attr are not counted, since Slog logs them as attributes.
+//
+// Calls like That(f()) are checked to spread a (bool, string) result of a reusable assertion.
+// Reusable assertions are also checked not to return a message when they pass.
+//
+// Calls to That with a constant true condition are reported, since they can never fail.
+//
+// The analyzer is a separate module, so that the assertions themselves do not depend on
+// the Go version required by golang.org/x/tools.
+package assertcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `check calls to Asserter.That and ErrorFuncs, and the shape of reusable assertions
+
+Calls to Asserter.That, Invariant, contract.Requires and ErrorFuncs are checked like calls to fmt.Printf,
+except that arguments of type slog.Attr are not counted.
+Calls like That(f()) are checked to spread the (bool, string) result of a reusable assertion,
+and reusable assertions are checked not to return a message when they pass.
+Calls to That with a constant true condition are reported, since they can never fail.`
+
+// Analyzer reports misuse of assertions.
+var Analyzer = &analysis.Analyzer{
+	Name:     "assertcheck",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const (
+	assertPath   = "github.com/szabba/assert/v2"
+	contractPath = "github.com/szabba/assert/v2/contract"
+)
+
+// A printfLike describes where the arguments of a function checked like fmt.Printf are.
+type printfLike struct {
+	name      string
+	cond      int // The index of the condition, or -1 when there is none.
+	format    int
+	canBeTrue bool
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+	inspect.Preorder(nodes, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if fn, ok := printfLikeCallee(pass, n); ok {
+				checkCall(pass, n, fn)
+			}
+		case *ast.FuncDecl:
+			if fn, ok := pass.TypesInfo.Defs[n.Name].(*types.Func); ok {
+				checkAssertion(pass, fn.Type().(*types.Signature), n.Body)
+			}
+		case *ast.FuncLit:
+			if sig, ok := pass.TypesInfo.TypeOf(n).(*types.Signature); ok {
+				checkAssertion(pass, sig, n.Body)
+			}
+		}
+	})
+	return nil, nil
+}
+
+func printfLikeCallee(pass *analysis.Pass, call *ast.CallExpr) (printfLike, bool) {
+	if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && !tv.IsType() && isNamed(tv.Type, assertPath, "ErrorFunc") {
+		return printfLike{name: "ErrorFunc", cond: -1, format: 0}, true
+	}
+
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return printfLike{}, false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	switch {
+	case recv != nil && isNamed(recv.Type(), assertPath, "Asserter") && fn.Name() == "That":
+		return printfLike{name: "That", cond: 0, format: 1}, true
+	case recv == nil && fn.Pkg().Path() == assertPath && fn.Name() == "Invariant":
+		return printfLike{name: "Invariant", cond: 0, format: 1}, true
+	case fn.Pkg().Path() == contractPath && fn.Name() == "Requires":
+		return printfLike{name: "Requires", cond: 0, format: 1}, true
+	}
+	return printfLike{}, false
+}
+
+func isNamed(t types.Type, path, name string) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == path && obj.Name() == name
+}
+
+func checkCall(pass *analysis.Pass, call *ast.CallExpr, fn printfLike) {
+	if len(call.Args) == 1 && fn.cond == 0 {
+		checkSpread(pass, call, fn)
+		return
+	}
+	if len(call.Args) <= fn.format {
+		return
+	}
+
+	if fn.cond >= 0 {
+		if cond := call.Args[fn.cond]; isConstTrue(pass, cond) {
+			pass.Reportf(cond.Pos(), "%s is given a constant true condition, so it can never fail", fn.name)
+		}
+	}
+
+	format := call.Args[fn.format]
+	if call.Ellipsis.IsValid() {
+		return
+	}
+	args := formatted(pass, call.Args[fn.format+1:])
+
+	tv := pass.TypesInfo.Types[format]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		if len(args) == 0 {
+			pass.Reportf(format.Pos(), "non-constant format string in call to %s, use \"%%s\" as the format instead", fn.name)
+		}
+		return
+	}
+
+	verbs, err := countVerbs(constant.StringVal(tv.Value))
+	switch {
+	case err != nil:
+		pass.Reportf(format.Pos(), "%s format %s", fn.name, err)
+	case verbs >= 0 && verbs != len(args):
+		pass.Reportf(call.Pos(), "%s call needs %s but has %s", fn.name, plural(verbs, "arg"), plural(len(args), "arg"))
+	}
+}
+
+// formatted returns the arguments that the format string applies to.
+//
+// Arguments of type slog.Attr are left out, since Slog adds them to the log record instead of formatting them.
+func formatted(pass *analysis.Pass, args []ast.Expr) []ast.Expr {
+	var out []ast.Expr
+	for _, arg := range args {
+		if !isNamed(pass.TypesInfo.TypeOf(arg), "log/slog", "Attr") {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// checkSpread checks a call like That(f()), where f should be a reusable assertion.
+func checkSpread(pass *analysis.Pass, call *ast.CallExpr, fn printfLike) {
+	inner, ok := call.Args[0].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	tuple, ok := pass.TypesInfo.TypeOf(inner).(*types.Tuple)
+	if !ok || isAssertionResult(tuple) {
+		return
+	}
+	pass.Reportf(inner.Pos(), "%s is given the result %s, reusable assertions should return (bool, string)", fn.name, tuple)
+}
+
+// checkAssertion checks that a function returning (bool, string) does not return a message when it passes.
+func checkAssertion(pass *analysis.Pass, sig *types.Signature, body *ast.BlockStmt) {
+	if body == nil || !isAssertionResult(sig.Results()) {
+		return
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != 2 {
+				return true
+			}
+			if !isConstTrue(pass, n.Results[0]) {
+				return true
+			}
+			tv := pass.TypesInfo.Types[n.Results[1]]
+			if tv.Value != nil && tv.Value.Kind() == constant.String && constant.StringVal(tv.Value) != "" {
+				pass.Reportf(n.Results[1].Pos(), "assertion returns a non-empty message when it passes")
+			}
+		}
+		return true
+	})
+}
+
+// isConstTrue reports whether expr is a boolean constant expression that evaluates to true.
+func isConstTrue(pass *analysis.Pass, expr ast.Expr) bool {
+	v := pass.TypesInfo.Types[expr].Value
+	return v != nil && v.Kind() == constant.Bool && constant.BoolVal(v)
+}
+
+func isAssertionResult(t *types.Tuple) bool {
+	if t.Len() != 2 {
+		return false
+	}
+	first, ok1 := t.At(0).Type().Underlying().(*types.Basic)
+	second, ok2 := t.At(1).Type().Underlying().(*types.Basic)
+	return ok1 && ok2 && first.Kind() == types.Bool && second.Kind() == types.String
+}
+
+// countVerbs counts the arguments a format string uses.
+//
+// It returns -1 when the format uses explicit argument indexes, since then the count cannot be checked simply.
+func countVerbs(format string) (int, error) {
+	n := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(format) && format[i] == '%' {
+			continue
+		}
+
+		for i < len(format) && isFlag(format[i]) {
+			i++
+		}
+		for i < len(format) && (isDigit(format[i]) || format[i] == '*' || format[i] == '.') {
+			if format[i] == '*' {
+				n++
+			}
+			i++
+		}
+		if i < len(format) && format[i] == '[' {
+			return -1, nil
+		}
+		if i >= len(format) {
+			return 0, fmt.Errorf("ends with a %% without a verb")
+		}
+
+		_, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		n++
+	}
+	return n, nil
+}
+
+func isFlag(c byte) bool {
+	return c == '+' || c == '-' || c == '#' || c == ' ' || c == '0'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
@@ -0,0 +1,66 @@
+package a
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/contract"
+)
+
+func Positive(n int) (bool, string) {
+	if n > 0 {
+		return true, ""
+	}
+	return false, fmt.Sprintf("got %d <= 0", n)
+}
+
+func Negative(n int) (bool, string) {
+	if n < 0 {
+		return true, "ok" // want `assertion returns a non-empty message when it passes`
+	}
+	return false, fmt.Sprintf("got %d >= 0", n)
+}
+
+func Enabled() (bool, string) {
+	return enabled, "always" // want `assertion returns a non-empty message when it passes`
+}
+
+func Verbose(n int) (bool, string, any) { return n > 0, "got %d", n }
+
+func Valid(n int) bool { return n > 0 }
+
+const enabled = true
+
+func calls(onErr assert.ErrorFunc, n int, msg string, args []any) {
+	a := assert.Using(onErr)
+
+	a.That(Positive(n))
+	a.That(n > 0, "got %d", n)
+	a.That(n > 0, "got 100%% of %d", n)
+	a.That(n > 0, "got %*d", 3, n)
+	a.That(n > 0, "got %[1]d %[1]d", n)
+	a.That(n > 0, msg, args...)
+	a.That(n > 0, "%s", msg)
+
+	a.That(n > 0, "got %d")        // want `That call needs 1 arg but has 0 args`
+	a.That(n > 0, "got %d", n, n)  // want `That call needs 1 arg but has 2 args`
+	a.That(n > 0, "got %")         // want `That format ends with a % without a verb`
+	a.That(n > 0, msg)             // want `non-constant format string in call to That`
+	a.That(true, "never fails")    // want `That is given a constant true condition, so it can never fail`
+	a.That(1 == 1, "never fails")  // want `That is given a constant true condition, so it can never fail`
+	a.That(enabled, "never fails") // want `That is given a constant true condition, so it can never fail`
+	a.That(false, "always fails")
+	a.That(Verbose(n)) // want `That is given the result \(bool, string, any\), reusable assertions should return \(bool, string\)`
+
+	a.That(n > 0, "got %d", n, slog.Int("n", n), slog.String("unit", "items"))
+	a.That(n > 0, "got %s", assert.Lazy(func() string { return "n" }))
+	a.That(n > 0, "got %d %s", n, slog.Int("n", n)) // want `That call needs 2 args but has 1 arg`
+
+	onErr("got %d")                             // want `ErrorFunc call needs 1 arg but has 0 args`
+	onErr(msg)                                  // want `non-constant format string in call to ErrorFunc`
+	assert.Invariant(n > 0, "%d %d", n)         // want `Invariant call needs 2 args but has 1 arg`
+	contract.Requires(Valid(n), "got %s", n, n) // want `Requires call needs 1 arg but has 2 args`
+
+	_ = assert.ErrorFunc(nil)
+}
@@ -0,0 +1,15 @@
+package assert
+
+type ErrorFunc func(msgFmt string, args ...any)
+
+type Asserter struct{ onErr ErrorFunc }
+
+func Using(onErr ErrorFunc) Asserter { return Asserter{onErr} }
+
+func (a Asserter) That(cond bool, msgFmt string, args ...any) Asserter { return a }
+
+func Invariant(cond bool, msgFmt string, args ...any) {}
+
+type Lazy func() string
+
+func (l Lazy) String() string { return l() }
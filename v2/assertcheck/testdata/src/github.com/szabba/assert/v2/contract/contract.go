@@ -0,0 +1,3 @@
+package contract
+
+func Requires(cond bool, msgFmt string, args ...any) {}
@@ -0,0 +1,139 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assertiontesting
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+// Conformance describes a reusable assertion together with examples of inputs for which it passes and fails.
+//
+// Assertions with multiple arguments can be described using Pair and OnPair.
+type Conformance[In any] struct {
+	// Assertion is the reusable assertion being checked.
+	Assertion func(In) (bool, string)
+
+	// Not is the optional complement of Assertion, like theval.NotEqual is for theval.Equal.
+	Not func(In) (bool, string)
+
+	// Pass holds inputs for which Assertion passes.
+	Pass []In
+
+	// Fail holds inputs for which Assertion fails.
+	Fail []In
+}
+
+// Run checks that the assertion behaves like all the reusable assertions should.
+//
+// Every example is checked in a separate subtest of t.
+// For each example Run checks that the assertion
+//
+//   - passes with an empty message or fails with a non-empty one, as the example says,
+//   - returns a message that does not contain fmt formatting errors like %!d(string=...),
+//   - returns a message that That reports unchanged, even though it uses it as a format string.
+//
+// When Not is set, the same is checked for Not, expecting it to fail exactly when Assertion passes.
+func (c Conformance[In]) Run(t *testing.T) {
+	t.Helper()
+
+	for i, in := range c.Pass {
+		in := in
+		t.Run(fmt.Sprintf("Pass/%02d", i), func(t *testing.T) {
+			t.Helper()
+			c.check(t, in, true)
+		})
+	}
+
+	for i, in := range c.Fail {
+		in := in
+		t.Run(fmt.Sprintf("Fail/%02d", i), func(t *testing.T) {
+			t.Helper()
+			c.check(t, in, false)
+		})
+	}
+}
+
+func (c Conformance[In]) check(t *testing.T, in In, wantOK bool) {
+	t.Helper()
+
+	for _, problem := range conformanceProblems("assertion", c.Assertion, in, wantOK) {
+		t.Error(problem)
+	}
+	if c.Not == nil {
+		return
+	}
+	for _, problem := range conformanceProblems("complement", c.Not, in, !wantOK) {
+		t.Error(problem)
+	}
+}
+
+func conformanceProblems[In any](role string, assertion func(In) (bool, string), in In, wantOK bool) []string {
+	ok, msg := assertion(in)
+
+	var problems []string
+	switch {
+	case ok != wantOK && wantOK:
+		problems = append(problems, fmt.Sprintf("%s failed for %#v with message %q", role, in, msg))
+	case ok != wantOK:
+		problems = append(problems, fmt.Sprintf("%s passed for %#v", role, in))
+	case ok && msg != "":
+		problems = append(problems, fmt.Sprintf("%s passed for %#v with non-empty message %q", role, in, msg))
+	case !ok && msg == "":
+		problems = append(problems, fmt.Sprintf("%s failed for %#v with an empty message", role, in))
+	}
+	if strings.Contains(msg, "%!") {
+		problems = append(problems, fmt.Sprintf("%s message for %#v contains a formatting error: %q", role, in, msg))
+	} else if got := reported(msg); got != msg {
+		problems = append(problems, fmt.Sprintf("%s message for %#v is reported by That as %q, not %q", role, in, got, msg))
+	}
+	return problems
+}
+
+// reported returns the message That reports for a failed assertion returning msg.
+//
+// That uses the message as a format string, so a % in it garbles what gets reported.
+func reported(msg string) string {
+	var rec Recorder
+	assert.Using(rec.Record).That(func() (bool, string) { return false, msg }())
+	return rec.Calls()[0].Message()
+}
+
+// A Pair holds the two arguments of an assertion.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// PairOf creates a Pair from the arguments of an assertion.
+func PairOf[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{first, second}
+}
+
+// OnPair adapts an assertion with two arguments to take a Pair, so that it can be described using a Conformance.
+func OnPair[A, B any](assertion func(A, B) (bool, string)) func(Pair[A, B]) (bool, string) {
+	return func(p Pair[A, B]) (bool, string) { return assertion(p.First, p.Second) }
+}
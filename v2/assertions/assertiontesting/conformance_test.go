@@ -0,0 +1,71 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assertiontesting
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConformanceProblems(t *testing.T) {
+	broken := func(n int) (bool, string) {
+		switch n {
+		case 0:
+			return true, ""
+		case 1:
+			return true, "chatty"
+		case 2:
+			return false, ""
+		case 4:
+			return false, fmt.Sprintf("got %q", "100%")
+		case 5:
+			return false, fmt.Sprintf("got %q", "100%%")
+		default:
+			return false, "got %!d(string=n)"
+		}
+	}
+
+	for _, tt := range []struct {
+		in     int
+		wantOK bool
+		want   []string
+	}{
+		{0, true, nil},
+		{0, false, []string{"assertion passed for 0"}},
+		{1, true, []string{`assertion passed for 1 with non-empty message "chatty"`}},
+		{2, false, []string{"assertion failed for 2 with an empty message"}},
+		{3, true, []string{
+			`assertion failed for 3 with message "got %!d(string=n)"`,
+			`assertion message for 3 contains a formatting error: "got %!d(string=n)"`,
+		}},
+		{4, false, nil},
+		{5, false, []string{
+			`assertion message for 5 is reported by That as "got \"100%\"", not "got \"100%%\""`,
+		}},
+	} {
+		got := conformanceProblems("assertion", broken, tt.in, tt.wantOK)
+		if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", tt.want) {
+			t.Errorf("got problems %q for %d, not %q", got, tt.in, tt.want)
+		}
+	}
+}
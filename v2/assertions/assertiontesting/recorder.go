@@ -0,0 +1,158 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assertiontesting
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// A Call holds the arguments of a single call to an error function.
+type Call struct {
+	MsgFmt string
+	Args   []any
+}
+
+// Message returns the message formatted from the arguments of the call.
+func (c Call) Message() string {
+	return fmt.Sprintf(c.MsgFmt, c.Args...)
+}
+
+// Recorder is an object that records every call to an error function, in order.
+//
+// Unlike ErrFunc, it can record any number of calls.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record is a method that can be used as an error function.
+//
+// Pass r.Record to assert.Using in order to test code that can report multiple failures.
+func (r *Recorder) Record(msgFmt string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{msgFmt, args})
+}
+
+// Calls returns the calls recorded so far, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call{}, r.calls...)
+}
+
+// Messages returns the messages of the calls recorded so far, in order.
+//
+// They can be compared to a golden file using thegolden.
+func (r *Recorder) Messages() []string {
+	calls := r.Calls()
+	msgs := make([]string, len(calls))
+	for i, c := range calls {
+		msgs[i] = c.Message()
+	}
+	return msgs
+}
+
+// CallCount is a helper to assert that the error function was called n times.
+func (r *Recorder) CallCount(n int) (bool, string) {
+	got := len(r.Calls())
+	if got == n {
+		return true, ""
+	}
+	return false, fmt.Sprintf("error func was called %d times, not %d", got, n)
+}
+
+// MessageContains is a helper to assert that the message of the i-th call contains substr.
+//
+// The calls are numbered from zero.
+func (r *Recorder) MessageContains(i int, substr string) (bool, string) {
+	c, ok, msg := r.call(i)
+	if !ok {
+		return false, msg
+	}
+	got := c.Message()
+	if strings.Contains(got, substr) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("message of call %d %q does not contain %q", i, got, substr)
+}
+
+// MessageMatches is a helper to assert that the message of the i-th call matches re.
+//
+// The calls are numbered from zero.
+func (r *Recorder) MessageMatches(i int, re *regexp.Regexp) (bool, string) {
+	c, ok, msg := r.call(i)
+	if !ok {
+		return false, msg
+	}
+	got := c.Message()
+	if re.MatchString(got) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("message of call %d %q does not match %s", i, got, re)
+}
+
+// ArgsEqual is a helper to assert that the i-th call received the given args, compared using reflect.DeepEqual.
+//
+// The calls are numbered from zero.
+func (r *Recorder) ArgsEqual(i int, want ...any) (bool, string) {
+	c, ok, msg := r.call(i)
+	if !ok {
+		return false, msg
+	}
+	if len(c.Args) == 0 && len(want) == 0 || reflect.DeepEqual(c.Args, want) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("call %d got args %#v, not %#v", i, c.Args, want)
+}
+
+// MessagesFormatTo is a helper to assert that the error function was called with exactly the messages given, in order.
+func (r *Recorder) MessagesFormatTo(want ...string) (bool, string) {
+	got := r.Messages()
+
+	var diffs []string
+	for i := 0; i < len(got) || i < len(want); i++ {
+		switch {
+		case i >= len(want):
+			diffs = append(diffs, fmt.Sprintf("unexpected message %d %q", i, got[i]))
+		case i >= len(got):
+			diffs = append(diffs, fmt.Sprintf("missing message %d %q", i, want[i]))
+		case got[i] != want[i]:
+			diffs = append(diffs, fmt.Sprintf("message %d formats to %q, not %q", i, got[i], want[i]))
+		}
+	}
+	return len(diffs) == 0, strings.Join(diffs, "; ")
+}
+
+func (r *Recorder) call(i int) (Call, bool, string) {
+	calls := r.Calls()
+	if i < 0 || i >= len(calls) {
+		return Call{}, false, fmt.Sprintf("error func was called %d times, there is no call %d", len(calls), i)
+	}
+	return calls[i], true, ""
+}
@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assertiontesting_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+)
+
+func TestRecorder(t *testing.T) {
+	// given
+	var rec assertiontesting.Recorder
+
+	// when
+	assert.Using(rec.Record).
+		That(false, "got %d, not %d", 1, 2).
+		That(true, "not recorded").
+		That(false, "no args")
+
+	// then
+	assert.Using(t.Errorf).
+		That(rec.CallCount(2)).
+		That(rec.MessageContains(0, "not 2")).
+		That(rec.MessageMatches(1, regexp.MustCompile(`^no \w+$`))).
+		That(rec.ArgsEqual(0, 1, 2)).
+		That(rec.ArgsEqual(1)).
+		That(rec.MessagesFormatTo("got 1, not 2", "no args"))
+}
+
+func TestRecorderFailures(t *testing.T) {
+	// given
+	var rec assertiontesting.Recorder
+	assert.Using(rec.Record).That(false, "got %d, not %d", 1, 2)
+
+	// when
+	var got assertiontesting.Recorder
+	assert.Using(got.Record).
+		That(rec.CallCount(2)).
+		That(rec.MessageContains(0, "3")).
+		That(rec.MessageMatches(1, regexp.MustCompile(`.`))).
+		That(rec.ArgsEqual(0, 1, 3)).
+		That(rec.MessagesFormatTo("got 1, not 3", "other"))
+
+	// then
+	assert.Using(t.Errorf).That(got.MessagesFormatTo(
+		"error func was called 1 times, not 2",
+		`message of call 0 "got 1, not 2" does not contain "3"`,
+		"error func was called 1 times, there is no call 1",
+		"call 0 got args []interface {}{1, 2}, not []interface {}{1, 3}",
+		`message 0 formats to "got 1, not 2", not "got 1, not 3"; missing message 1 "other"`,
+	))
+}
+
+func TestRecorderConformance(t *testing.T) {
+	var rec assertiontesting.Recorder
+	assert.Using(rec.Record).
+		That(false, "got %d, not %d", 1, 2).
+		That(false, "no args")
+
+	t.Run("CallCount", func(t *testing.T) {
+		assertiontesting.Conformance[int]{
+			Assertion: rec.CallCount,
+			Pass:      []int{2},
+			Fail:      []int{0, 1, 3},
+		}.Run(t)
+	})
+
+	t.Run("MessageContains", func(t *testing.T) {
+		p := assertiontesting.PairOf[int, string]
+		assertiontesting.Conformance[assertiontesting.Pair[int, string]]{
+			Assertion: assertiontesting.OnPair(rec.MessageContains),
+			Pass:      []assertiontesting.Pair[int, string]{p(0, "not 2"), p(1, "")},
+			Fail:      []assertiontesting.Pair[int, string]{p(0, "3"), p(2, "")},
+		}.Run(t)
+	})
+
+	t.Run("MessageMatches", func(t *testing.T) {
+		p := assertiontesting.PairOf[int, *regexp.Regexp]
+		assertiontesting.Conformance[assertiontesting.Pair[int, *regexp.Regexp]]{
+			Assertion: assertiontesting.OnPair(rec.MessageMatches),
+			Pass:      []assertiontesting.Pair[int, *regexp.Regexp]{p(0, regexp.MustCompile(`^got`)), p(1, regexp.MustCompile(`^no \w+$`))},
+			Fail:      []assertiontesting.Pair[int, *regexp.Regexp]{p(0, regexp.MustCompile(`^no`)), p(2, regexp.MustCompile(`.`))},
+		}.Run(t)
+	})
+
+	t.Run("ArgsEqual", func(t *testing.T) {
+		p := assertiontesting.PairOf[int, []any]
+		assertiontesting.Conformance[assertiontesting.Pair[int, []any]]{
+			Assertion: assertiontesting.OnPair(func(i int, want []any) (bool, string) { return rec.ArgsEqual(i, want...) }),
+			Pass:      []assertiontesting.Pair[int, []any]{p(0, []any{1, 2}), p(1, nil)},
+			Fail:      []assertiontesting.Pair[int, []any]{p(0, []any{1, 3}), p(1, []any{1}), p(2, nil)},
+		}.Run(t)
+	})
+}
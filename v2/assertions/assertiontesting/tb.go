@@ -0,0 +1,287 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assertiontesting
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// TB is a fake testing.TB that records what the code under test does with it.
+//
+// Use it to test helpers that take a testing.TB, like ones built on assert.Using(t.Fatalf).
+// The code under test has to be called through Run, so that FailNow and SkipNow can stop it
+// without stopping the real test.
+//
+// TB records the logs, failures, skips and the functions that marked themselves as helpers.
+// Cleanup functions are run when the function passed to Run finishes, in reverse order of registration.
+//
+// The zero value is ready to use.
+// TB implements the methods of testing.TB up to Go 1.26, when built with a version of Go that has them.
+// The Context is canceled just before the cleanup functions run,
+// and the lines written to Output are recorded with the logs.
+type TB struct {
+	// testing.TB is embedded only to implement its unexported method.
+	testing.TB
+
+	mu       sync.Mutex
+	logs     []string
+	helpers  []string
+	attrs    map[string]string
+	output   []byte
+	cleanups []func()
+	failed   bool
+	skipped  bool
+	stopped  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var _ testing.TB = (*TB)(nil)
+
+// Run calls f with tb in a new goroutine and waits for it to finish.
+//
+// Afterwards it runs the cleanup functions registered during the call.
+// If f panics, Run panics with the same value once the cleanups are done.
+func (tb *TB) Run(f func(tb testing.TB)) {
+	var panicked any
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer tb.runCleanups()
+
+		returned := false
+		defer func() {
+			if !returned {
+				panicked = recover()
+			}
+		}()
+
+		f(tb)
+		returned = true
+	}()
+
+	<-done
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// Logs returns the messages logged so far, in order.
+//
+// Like with a real test, the messages passed to Error, Fatal and Skip methods are logged too.
+func (tb *TB) Logs() []string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return append([]string{}, tb.logs...)
+}
+
+// Helpers returns the names of the functions that called Helper, in order.
+func (tb *TB) Helpers() []string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return append([]string{}, tb.helpers...)
+}
+
+// Stopped tells whether the function passed to Run was stopped by FailNow or SkipNow.
+func (tb *TB) Stopped() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.stopped
+}
+
+// Cleanup registers f to be called when the function passed to Run finishes.
+func (tb *TB) Cleanup(f func()) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+// Error is equivalent to Log followed by Fail.
+func (tb *TB) Error(args ...any) {
+	tb.Log(args...)
+	tb.Fail()
+}
+
+// Errorf is equivalent to Logf followed by Fail.
+func (tb *TB) Errorf(format string, args ...any) {
+	tb.Logf(format, args...)
+	tb.Fail()
+}
+
+// Fail marks the test as failed.
+func (tb *TB) Fail() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.failed = true
+}
+
+// FailNow marks the test as failed and stops the function passed to Run.
+//
+// Like with a real test, it must be called from the goroutine running that function.
+func (tb *TB) FailNow() {
+	tb.Fail()
+	tb.stop()
+}
+
+// Failed tells whether the test has failed.
+func (tb *TB) Failed() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.failed
+}
+
+// Fatal is equivalent to Log followed by FailNow.
+func (tb *TB) Fatal(args ...any) {
+	tb.Log(args...)
+	tb.FailNow()
+}
+
+// Fatalf is equivalent to Logf followed by FailNow.
+func (tb *TB) Fatalf(format string, args ...any) {
+	tb.Logf(format, args...)
+	tb.FailNow()
+}
+
+// Helper records the name of the calling function.
+func (tb *TB) Helper() {
+	name := "unknown function"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		name = runtime.FuncForPC(pc).Name()
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.helpers = append(tb.helpers, name)
+}
+
+// Log records a message formatted like by fmt.Sprintln, without the final newline.
+func (tb *TB) Log(args ...any) {
+	msg := fmt.Sprintln(args...)
+	tb.log(msg[:len(msg)-1])
+}
+
+// Logf records a message formatted like by fmt.Sprintf.
+func (tb *TB) Logf(format string, args ...any) {
+	tb.log(fmt.Sprintf(format, args...))
+}
+
+// Name returns the name of the fake test.
+func (tb *TB) Name() string {
+	return "FakeTest"
+}
+
+// Setenv sets an environment variable and restores its previous value during cleanup.
+func (tb *TB) Setenv(key, value string) {
+	prev, existed := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		tb.Fatalf("cannot set environment variable: %s", err)
+	}
+	tb.Cleanup(func() {
+		if existed {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// Skip is equivalent to Log followed by SkipNow.
+func (tb *TB) Skip(args ...any) {
+	tb.Log(args...)
+	tb.SkipNow()
+}
+
+// SkipNow marks the test as skipped and stops the function passed to Run.
+//
+// Like with a real test, it must be called from the goroutine running that function.
+func (tb *TB) SkipNow() {
+	tb.mu.Lock()
+	tb.skipped = true
+	tb.mu.Unlock()
+	tb.stop()
+}
+
+// Skipf is equivalent to Logf followed by SkipNow.
+func (tb *TB) Skipf(format string, args ...any) {
+	tb.Logf(format, args...)
+	tb.SkipNow()
+}
+
+// Skipped tells whether the test was skipped.
+func (tb *TB) Skipped() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.skipped
+}
+
+// TempDir creates a new temporary directory that is removed during cleanup.
+func (tb *TB) TempDir() string {
+	dir, err := os.MkdirTemp("", "assertiontesting")
+	if err != nil {
+		tb.Fatalf("cannot create temporary directory: %s", err)
+	}
+	tb.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func (tb *TB) log(msg string) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.logs = append(tb.logs, msg)
+}
+
+func (tb *TB) stop() {
+	tb.mu.Lock()
+	tb.stopped = true
+	tb.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (tb *TB) runCleanups() {
+	tb.mu.Lock()
+	cancel := tb.cancel
+	tb.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+
+	for {
+		tb.mu.Lock()
+		if len(tb.cleanups) == 0 {
+			tb.mu.Unlock()
+			return
+		}
+		last := tb.cleanups[len(tb.cleanups)-1]
+		tb.cleanups = tb.cleanups[:len(tb.cleanups)-1]
+		tb.mu.Unlock()
+
+		last()
+	}
+}
@@ -0,0 +1,52 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.24
+
+package assertiontesting
+
+import (
+	"context"
+	"os"
+)
+
+// Context returns a context that is canceled just before the cleanup functions run.
+func (tb *TB) Context() context.Context {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if tb.ctx == nil {
+		tb.ctx, tb.cancel = context.WithCancel(context.Background())
+	}
+	return tb.ctx
+}
+
+// Chdir changes the working directory and restores the previous one during cleanup.
+func (tb *TB) Chdir(dir string) {
+	prev, err := os.Getwd()
+	if err != nil {
+		tb.Fatalf("cannot get working directory: %s", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		tb.Fatalf("cannot change working directory: %s", err)
+	}
+	tb.Cleanup(func() { os.Chdir(prev) })
+}
@@ -0,0 +1,74 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.25
+
+package assertiontesting
+
+import (
+	"bytes"
+	"io"
+)
+
+// Attr records an attribute of the test.
+func (tb *TB) Attr(key, value string) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if tb.attrs == nil {
+		tb.attrs = map[string]string{}
+	}
+	tb.attrs[key] = value
+}
+
+// Attrs returns the attributes recorded so far.
+func (tb *TB) Attrs() map[string]string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	attrs := make(map[string]string, len(tb.attrs))
+	for k, v := range tb.attrs {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// Output returns a writer that records each complete line written to it as a log message.
+func (tb *TB) Output() io.Writer {
+	return outputWriter{tb}
+}
+
+type outputWriter struct{ tb *TB }
+
+func (w outputWriter) Write(p []byte) (int, error) {
+	tb := w.tb
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.output = append(tb.output, p...)
+	for {
+		i := bytes.IndexByte(tb.output, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		tb.logs = append(tb.logs, string(tb.output[:i]))
+		tb.output = tb.output[i+1:]
+	}
+}
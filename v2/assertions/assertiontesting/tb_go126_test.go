@@ -0,0 +1,53 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.26
+
+package assertiontesting_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+)
+
+func TestTBRemovesArtifactDir(t *testing.T) {
+	// given
+	var tb assertiontesting.TB
+	var dir string
+	var statErr error
+
+	// when
+	tb.Run(func(t testing.TB) {
+		dir = t.ArtifactDir()
+		_, statErr = os.Stat(dir)
+	})
+
+	// then
+	_, afterErr := os.Stat(dir)
+	assert.Using(t.Errorf).
+		That(statErr == nil, "artifact directory was not created: %v", statErr).
+		That(os.IsNotExist(afterErr), "artifact directory was not removed: %v", afterErr)
+}
@@ -0,0 +1,135 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assertiontesting_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+)
+
+func requirePositive(t testing.TB, n int) {
+	t.Helper()
+	assert.Using(t.Fatalf).That(n > 0, "got %d, not a positive number", n)
+}
+
+func TestTBFailNowStopsTheHelper(t *testing.T) {
+	// given
+	var tb assertiontesting.TB
+	reached := false
+
+	// when
+	tb.Run(func(t testing.TB) {
+		requirePositive(t, 0)
+		reached = true
+	})
+
+	// then
+	assert.Using(t.Errorf).
+		That(tb.Failed(), "fake test did not fail").
+		That(tb.Stopped(), "fake test was not stopped").
+		That(!reached, "code after FailNow was reached").
+		That(theslice.Equal(tb.Logs(), []string{"got 0, not a positive number"})).
+		That(theslice.Equal(tb.Helpers(), []string{
+			"github.com/szabba/assert/v2/assertions/assertiontesting_test.requirePositive",
+		}))
+}
+
+func TestTBPassingHelper(t *testing.T) {
+	// given
+	var tb assertiontesting.TB
+
+	// when
+	tb.Run(func(t testing.TB) { requirePositive(t, 1) })
+
+	// then
+	assert.Using(t.Errorf).
+		That(!tb.Failed(), "fake test failed").
+		That(!tb.Stopped(), "fake test was stopped").
+		That(theslice.Empty(tb.Logs()))
+}
+
+func TestTBRunsCleanupsInReverseOrder(t *testing.T) {
+	// given
+	var tb assertiontesting.TB
+	var order []string
+
+	// when
+	tb.Run(func(t testing.TB) {
+		t.Cleanup(func() { order = append(order, "first") })
+		t.Cleanup(func() { order = append(order, "second") })
+		t.Skip("skipping", "now")
+	})
+
+	// then
+	assert.Using(t.Errorf).
+		That(tb.Skipped(), "fake test was not skipped").
+		That(!tb.Failed(), "fake test failed").
+		That(theslice.Equal(order, []string{"second", "first"})).
+		That(theslice.Equal(tb.Logs(), []string{"skipping now"}))
+}
+
+func TestTBRestoresEnvironmentAndRemovesTempDirs(t *testing.T) {
+	// given
+	var tb assertiontesting.TB
+	const key = "ASSERTIONTESTING_TB_TEST"
+	var dir string
+
+	// when
+	tb.Run(func(t testing.TB) {
+		t.Setenv(key, "set")
+		dir = t.TempDir()
+	})
+
+	// then
+	_, set := os.LookupEnv(key)
+	_, statErr := os.Stat(dir)
+	assert.Using(t.Errorf).
+		That(!set, "environment variable was not restored").
+		That(os.IsNotExist(statErr), "temporary directory was not removed: %v", statErr)
+}
+
+func TestTBRepanics(t *testing.T) {
+	// given
+	var tb assertiontesting.TB
+	cleanedUp := false
+
+	defer func() {
+		// then
+		msg, _ := recover().(string)
+		assert.Using(t.Errorf).
+			That(theval.Equal(msg, "Oops")).
+			That(cleanedUp, "cleanup did not run")
+	}()
+
+	// when
+	tb.Run(func(t testing.TB) {
+		t.Cleanup(func() { cleanedUp = true })
+		panic("Oops")
+	})
+}
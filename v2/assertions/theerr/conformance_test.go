@@ -0,0 +1,72 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/theerr"
+)
+
+func TestConformance(t *testing.T) {
+
+	t.Run("IsNil", func(t *testing.T) {
+		assertiontesting.Conformance[error]{
+			Assertion: theerr.IsNil,
+			Pass:      []error{nil},
+			Fail:      []error{io.EOF, errors.New("100% broken")},
+		}.Run(t)
+	})
+
+	t.Run("Is", func(t *testing.T) {
+		p := assertiontesting.PairOf[error, error]
+		assertiontesting.Conformance[assertiontesting.Pair[error, error]]{
+			Assertion: assertiontesting.OnPair(theerr.Is),
+			Pass: []assertiontesting.Pair[error, error]{
+				p(nil, nil),
+				p(io.EOF, io.EOF),
+				p(fmt.Errorf("reading: %w", io.EOF), io.EOF),
+			},
+			Fail: []assertiontesting.Pair[error, error]{
+				p(io.EOF, nil),
+				p(nil, io.EOF),
+				p(io.ErrUnexpectedEOF, io.EOF),
+			},
+		}.Run(t)
+	})
+
+	t.Run("IsA", func(t *testing.T) {
+		assertiontesting.Conformance[error]{
+			Assertion: theerr.IsA[*fs.PathError],
+			Pass:      []error{&fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}},
+			Fail:      []error{nil, io.EOF},
+		}.Run(t)
+	})
+
+}
@@ -49,6 +49,9 @@ func Is(got, want error) (bool, string) {
 		return true, ""
 	}
 
+	if got == nil {
+		return false, fmt.Sprintf("got no error, not %q", want)
+	}
 	return false, fmt.Sprintf("got %q, not %q", got, want)
 }
 
@@ -115,6 +115,19 @@ func TestIs(t *testing.T) {
 			That(errFunc.MessageFormatsTo(`got "oops", not "EOF"`))
 	})
 
+	t.Run("False/Nil", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(theerr.Is(nil, io.EOF))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`got no error, not "EOF"`))
+	})
+
 }
 
 func TestIsA(t *testing.T) {
@@ -0,0 +1,220 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thefuzz
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// A Codec converts between fuzzed bytes and values of type T.
+//
+// The encoding is compact and every value takes at least one byte.
+// Byte strings and slices are prefixed with their length.
+type Codec[T any] struct {
+	encode func(buf []byte, v T) []byte
+	decode func(data []byte) (v T, rest []byte, ok bool)
+}
+
+// Encode returns the bytes that decode to v.
+func (c Codec[T]) Encode(v T) []byte {
+	return c.encode(nil, v)
+}
+
+// Decode returns the value encoded in data.
+//
+// It reports whether data is a valid encoding of a value, with no bytes left over.
+func (c Codec[T]) Decode(data []byte) (T, bool) {
+	v, rest, ok := c.decode(data)
+	if !ok || len(rest) > 0 {
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// Bytes is a Codec of byte slices.
+func Bytes() Codec[[]byte] {
+	return Codec[[]byte]{
+		encode: func(buf []byte, v []byte) []byte {
+			buf = binary.AppendUvarint(buf, uint64(len(v)))
+			return append(buf, v...)
+		},
+		decode: func(data []byte) ([]byte, []byte, bool) {
+			n, rest, ok := decodeLength(data)
+			if !ok {
+				return nil, data, false
+			}
+			return append([]byte{}, rest[:n]...), rest[n:], true
+		},
+	}
+}
+
+// String is a Codec of strings.
+func String() Codec[string] {
+	return Map(Bytes(), func(b []byte) string { return string(b) }, func(s string) []byte { return []byte(s) })
+}
+
+// Int is a Codec of integers.
+func Int() Codec[int] {
+	return Map(Int64(), func(n int64) int { return int(n) }, func(n int) int64 { return int64(n) })
+}
+
+// Int64 is a Codec of 64-bit integers.
+func Int64() Codec[int64] {
+	return Codec[int64]{
+		encode: binary.AppendVarint,
+		decode: func(data []byte) (int64, []byte, bool) {
+			n, size := binary.Varint(data)
+			if size <= 0 {
+				return 0, data, false
+			}
+			return n, data[size:], true
+		},
+	}
+}
+
+// Uint64 is a Codec of 64-bit unsigned integers.
+func Uint64() Codec[uint64] {
+	return Codec[uint64]{
+		encode: binary.AppendUvarint,
+		decode: func(data []byte) (uint64, []byte, bool) {
+			n, size := binary.Uvarint(data)
+			if size <= 0 {
+				return 0, data, false
+			}
+			return n, data[size:], true
+		},
+	}
+}
+
+// Float64 is a Codec of 64-bit floating point numbers.
+func Float64() Codec[float64] {
+	return Codec[float64]{
+		encode: func(buf []byte, v float64) []byte {
+			return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		},
+		decode: func(data []byte) (float64, []byte, bool) {
+			if len(data) < 8 {
+				return 0, data, false
+			}
+			return math.Float64frombits(binary.LittleEndian.Uint64(data)), data[8:], true
+		},
+	}
+}
+
+// Bool is a Codec of booleans.
+func Bool() Codec[bool] {
+	return Codec[bool]{
+		encode: func(buf []byte, v bool) []byte {
+			if v {
+				return append(buf, 1)
+			}
+			return append(buf, 0)
+		},
+		decode: func(data []byte) (bool, []byte, bool) {
+			if len(data) == 0 || data[0] > 1 {
+				return false, data, false
+			}
+			return data[0] == 1, data[1:], true
+		},
+	}
+}
+
+// SliceOf is a Codec of slices with elements encoded using elem.
+func SliceOf[T any](elem Codec[T]) Codec[[]T] {
+	return Codec[[]T]{
+		encode: func(buf []byte, vs []T) []byte {
+			buf = binary.AppendUvarint(buf, uint64(len(vs)))
+			for _, v := range vs {
+				buf = elem.encode(buf, v)
+			}
+			return buf
+		},
+		decode: func(data []byte) ([]T, []byte, bool) {
+			// Every element takes at least a byte, so the length cannot exceed what is left.
+			n, rest, ok := decodeLength(data)
+			if !ok {
+				return nil, data, false
+			}
+			vs := make([]T, n)
+			for i := range vs {
+				vs[i], rest, ok = elem.decode(rest)
+				if !ok {
+					return nil, data, false
+				}
+			}
+			return vs, rest, true
+		},
+	}
+}
+
+// Map creates a Codec of values of type U, encoded as values of type T using c.
+//
+// The functions to and from convert between the two types.
+func Map[T, U any](c Codec[T], to func(T) U, from func(U) T) Codec[U] {
+	return Codec[U]{
+		encode: func(buf []byte, v U) []byte { return c.encode(buf, from(v)) },
+		decode: func(data []byte) (U, []byte, bool) {
+			v, rest, ok := c.decode(data)
+			if !ok {
+				var zero U
+				return zero, data, false
+			}
+			return to(v), rest, true
+		},
+	}
+}
+
+type pair[A, B any] struct {
+	a A
+	b B
+}
+
+func pairOf[A, B any](ca Codec[A], cb Codec[B]) Codec[pair[A, B]] {
+	return Codec[pair[A, B]]{
+		encode: func(buf []byte, p pair[A, B]) []byte {
+			return cb.encode(ca.encode(buf, p.a), p.b)
+		},
+		decode: func(data []byte) (pair[A, B], []byte, bool) {
+			a, rest, ok := ca.decode(data)
+			if !ok {
+				return pair[A, B]{}, data, false
+			}
+			b, rest, ok := cb.decode(rest)
+			if !ok {
+				return pair[A, B]{}, data, false
+			}
+			return pair[A, B]{a, b}, rest, true
+		},
+	}
+}
+
+// decodeLength decodes a length prefix that does not exceed the number of bytes following it.
+func decodeLength(data []byte) (int, []byte, bool) {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n > uint64(len(data)-size) {
+		return 0, data, false
+	}
+	return int(n), data[size:], true
+}
@@ -0,0 +1,107 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thefuzz_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/thefuzz"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+
+	t.Run("Bytes", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.Bytes(), []byte("abc"), func(got, want []byte) (bool, string) {
+			return theslice.Equal(got, want)
+		})
+	})
+
+	t.Run("String", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.String(), "zażółć", theval.Equal[string])
+	})
+
+	t.Run("Int", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.Int(), -1234, theval.Equal[int])
+	})
+
+	t.Run("Uint64", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.Uint64(), 1<<63, theval.Equal[uint64])
+	})
+
+	t.Run("Float64", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.Float64(), -0.5, theval.Equal[float64])
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.Bool(), true, theval.Equal[bool])
+	})
+
+	t.Run("SliceOf", func(t *testing.T) {
+		testRoundTrip(t, thefuzz.SliceOf(thefuzz.String()), []string{"a", "", "bc"}, func(got, want []string) (bool, string) {
+			return theslice.Equal(got, want)
+		})
+	})
+
+}
+
+func testRoundTrip[T any](t *testing.T, c thefuzz.Codec[T], want T, equal func(got, want T) (bool, string)) {
+	t.Helper()
+
+	got, ok := c.Decode(c.Encode(want))
+
+	assert.Using(t.Errorf).
+		That(ok, "cannot decode encoded value %#v", want).
+		That(equal(got, want))
+}
+
+func TestCodecDecodeInvalid(t *testing.T) {
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, ok := thefuzz.String().Decode([]byte{3, 'a', 'b'})
+
+		assert.Using(t.Errorf).That(!ok, "decoded truncated input")
+	})
+
+	t.Run("LeftoverBytes", func(t *testing.T) {
+		_, ok := thefuzz.Bool().Decode([]byte{1, 0})
+
+		assert.Using(t.Errorf).That(!ok, "decoded input with leftover bytes")
+	})
+
+	t.Run("InvalidBool", func(t *testing.T) {
+		_, ok := thefuzz.Bool().Decode([]byte{2})
+
+		assert.Using(t.Errorf).That(!ok, "decoded invalid boolean")
+	})
+
+	t.Run("SliceLongerThanInput", func(t *testing.T) {
+		_, ok := thefuzz.SliceOf(thefuzz.Bool()).Decode([]byte{0xff, 0xff, 0xff, 0xff, 0x0f, 1})
+
+		assert.Using(t.Errorf).That(!ok, "decoded slice longer than the input")
+	})
+
+}
@@ -0,0 +1,174 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thefuzz turns reusable assertions into targets for native Go fuzzing.
+//
+// Any function of the fuzzed input that returns (bool, string) can be used as a fuzz target.
+// That way the same invariants checked in unit tests can serve as fuzzing oracles:
+//
+//	func FuzzParse(f *testing.F) {
+//	    thefuzz.SeedStrings(f, "1", "-42")
+//	    thefuzz.CheckString(f, func(s string) (bool, string) {
+//	        n, err := strconv.Atoi(s)
+//	        if err != nil {
+//	            return true, ""
+//	        }
+//	        return theval.Equal(strconv.Itoa(n), s)
+//	    })
+//	}
+//
+// A failing assertion stops the fuzz target using t.Fatalf, just like a required assertion in a unit test.
+//
+// # Typed arguments
+//
+// Functions like Check2 decode the fuzzed bytes into typed arguments using a Codec.
+// Inputs that cannot be decoded are skipped.
+// The same codecs encode the values of a seed corpus, see Seed2.
+package thefuzz
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/internal/property"
+)
+
+// Check fuzzes f with a target that asserts that prop holds for the fuzzed input.
+//
+// Inputs for which prop fails are reported using t.Fatalf.
+// A prop that panics fails as well.
+func Check(f *testing.F, prop func(input []byte) (bool, string)) {
+	f.Helper()
+	f.Fuzz(func(t *testing.T, input []byte) {
+		t.Helper()
+		ok, msg := holds(func() (bool, string) { return prop(input) })
+		assert.Using(t.Fatalf).That(ok, "%s", msg)
+	})
+}
+
+// CheckString is like Check, but the input is passed to prop as a string.
+func CheckString(f *testing.F, prop func(input string) (bool, string)) {
+	f.Helper()
+	Check(f, func(input []byte) (bool, string) { return prop(string(input)) })
+}
+
+// CheckWith fuzzes f with a target that asserts that prop holds for values decoded from the fuzzed input using c.
+//
+// Inputs that cannot be decoded are skipped.
+func CheckWith[T any](f *testing.F, c Codec[T], prop func(T) (bool, string)) {
+	f.Helper()
+	f.Fuzz(func(t *testing.T, input []byte) {
+		t.Helper()
+		v, ok := c.Decode(input)
+		if !ok {
+			t.Skip("input cannot be decoded")
+		}
+		ok, msg := holds(func() (bool, string) { return prop(v) })
+		assert.Using(t.Fatalf).That(ok, "%s", msg)
+	})
+}
+
+// Check2 is like CheckWith, but decodes two arguments for prop.
+func Check2[A, B any](f *testing.F, ca Codec[A], cb Codec[B], prop func(A, B) (bool, string)) {
+	f.Helper()
+	CheckWith(f, pairOf(ca, cb), func(p pair[A, B]) (bool, string) { return prop(p.a, p.b) })
+}
+
+// Check3 is like CheckWith, but decodes three arguments for prop.
+func Check3[A, B, C any](f *testing.F, ca Codec[A], cb Codec[B], cc Codec[C], prop func(A, B, C) (bool, string)) {
+	f.Helper()
+	CheckWith(f, pairOf(ca, pairOf(cb, cc)), func(p pair[A, pair[B, C]]) (bool, string) {
+		return prop(p.a, p.b.a, p.b.b)
+	})
+}
+
+// Seed adds inputs to the seed corpus of f.
+func Seed(f *testing.F, inputs ...[]byte) {
+	f.Helper()
+	for _, in := range inputs {
+		f.Add(in)
+	}
+}
+
+// SeedStrings adds inputs to the seed corpus of f.
+func SeedStrings(f *testing.F, inputs ...string) {
+	f.Helper()
+	for _, in := range inputs {
+		f.Add([]byte(in))
+	}
+}
+
+// SeedWith adds values encoded using c to the seed corpus of f.
+//
+// Use it together with CheckWith.
+func SeedWith[T any](f *testing.F, c Codec[T], values ...T) {
+	f.Helper()
+	for _, v := range values {
+		f.Add(c.Encode(v))
+	}
+}
+
+// Seed2 adds a pair of arguments encoded using the codecs to the seed corpus of f.
+//
+// Use it together with Check2 and the same codecs.
+func Seed2[A, B any](f *testing.F, ca Codec[A], cb Codec[B], a A, b B) {
+	f.Helper()
+	f.Add(pairOf(ca, cb).Encode(pair[A, B]{a, b}))
+}
+
+// Seed3 adds three arguments encoded using the codecs to the seed corpus of f.
+//
+// Use it together with Check3 and the same codecs.
+func Seed3[A, B, C any](f *testing.F, ca Codec[A], cb Codec[B], cc Codec[C], a A, b B, c C) {
+	f.Helper()
+	f.Add(pairOf(ca, pairOf(cb, cc)).Encode(pair[A, pair[B, C]]{a, pair[B, C]{b, c}}))
+}
+
+// SeedFiles adds the contents of the files matching the pattern to the seed corpus of f.
+//
+// The pattern has the syntax used by filepath.Glob.
+// SeedFiles fails the test when the pattern is malformed, matches no files, or a file cannot be read.
+func SeedFiles(f *testing.F, pattern string) {
+	f.Helper()
+
+	paths, err := filepath.Glob(pattern)
+	assert.Using(f.Fatalf).
+		That(err == nil, "invalid seed file pattern %q: %s", pattern, err).
+		That(len(paths) > 0, "no seed files match %q", pattern)
+
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		assert.Using(f.Fatalf).That(err == nil, "cannot read seed file: %s", err)
+		f.Add(input)
+	}
+}
+
+// holds checks prop, including the stack trace in the message when it panics.
+func holds(prop func() (bool, string)) (bool, string) {
+	ok, msg, stack := property.Holds(prop)
+	if stack != nil {
+		msg += "\n\n" + string(stack)
+	}
+	return ok, msg
+}
@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thefuzz_test
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/thefuzz"
+)
+
+func FuzzCheck(f *testing.F) {
+	thefuzz.Seed(f, []byte{}, []byte("abc"), []byte{0xff})
+
+	thefuzz.Check(f, func(input []byte) (bool, string) {
+		return theslice.Equal([]byte(string(input)), input)
+	})
+}
+
+func FuzzCheckString(f *testing.F) {
+	thefuzz.SeedStrings(f, "1", "-42", "x")
+
+	thefuzz.CheckString(f, func(s string) (bool, string) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return true, ""
+		}
+		m, _ := strconv.Atoi(strconv.Itoa(n))
+		return theval.Equal(m, n)
+	})
+}
+
+func FuzzCheck2(f *testing.F) {
+	thefuzz.Seed2(f, thefuzz.String(), thefuzz.Int(), "abc", 1)
+	thefuzz.Seed2(f, thefuzz.String(), thefuzz.Int(), "", -5)
+
+	thefuzz.Check2(f, thefuzz.String(), thefuzz.Int(), func(s string, n int) (bool, string) {
+		if n < 0 || n > len(s) || !utf8.ValidString(s) {
+			return true, ""
+		}
+		return theval.Equal(s[:n]+s[n:], s)
+	})
+}
+
+func FuzzCheck3(f *testing.F) {
+	thefuzz.Seed3(f, thefuzz.Int(), thefuzz.Int(), thefuzz.Bool(), 1, 2, true)
+
+	thefuzz.Check3(f, thefuzz.Int(), thefuzz.Int(), thefuzz.Bool(), func(a, b int, swap bool) (bool, string) {
+		if swap {
+			a, b = b, a
+		}
+		return theval.Equal(a+b, b+a)
+	})
+}
+
+func FuzzSeedFiles(f *testing.F) {
+	thefuzz.SeedFiles(f, "testdata/seeds/*")
+
+	thefuzz.CheckWith(f, thefuzz.SliceOf(thefuzz.Int()), func(ns []int) (bool, string) {
+		sorted := append([]int{}, ns...)
+		sort.Ints(sorted)
+		return theslice.Length(sorted, len(ns))
+	})
+}
@@ -0,0 +1,182 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thegolden provides assertions comparing values to the contents of golden files.
+//
+// A golden file holds the expected output of a test, like rendered HTML or generated code.
+// Golden files are kept in the testdata directory and named after the assertion, with a .golden extension.
+//
+//	thegolden.Equal(t, "page", renderPage())
+//
+// compares the output of renderPage to testdata/page.golden.
+//
+// # Updating golden files
+//
+// When tests are run with the THEGOLDEN_UPDATE environment variable set to a non-empty value,
+// or with the -update flag, the golden files are overwritten with the actual values instead of being compared to them:
+//
+//	THEGOLDEN_UPDATE=1 go test ./...
+//	go test ./pkg -update
+//
+// The -update flag is only defined in test binaries that import this package,
+// so do not pass it to go test with a pattern like ./... that matches other packages too.
+//
+// Importing this package defines the -update flag, unless a package initialized earlier already defined one.
+// Otherwise, a test package that imports it must not define a flag with the same name.
+package thegolden
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/internal/diff"
+	"github.com/szabba/assert/v2/internal/update"
+)
+
+// Dir is the directory golden files are kept in.
+var Dir = "testdata"
+
+// UpdateEnv is the name of the environment variable that enables updating golden files.
+const UpdateEnv = "THEGOLDEN_UPDATE"
+
+// Text is the content of a golden file.
+type Text interface{ ~[]byte | ~string }
+
+// Equal asserts that got is equal to the contents of the golden file with the given name.
+//
+// Failures are reported using t.Errorf.
+func Equal[T Text](t testing.TB, name string, got T) {
+	t.Helper()
+	ok, msg := Match(name, got)
+	assert.Using(t.Errorf).That(ok, "%s", msg)
+}
+
+// EqualJSON asserts that got is JSON equivalent to the contents of the golden file with the given name.
+//
+// Failures are reported using t.Errorf.
+// For more details see MatchJSON.
+func EqualJSON[T Text](t testing.TB, name string, got T) {
+	t.Helper()
+	ok, msg := MatchJSON(name, got)
+	assert.Using(t.Errorf).That(ok, "%s", msg)
+}
+
+// Match asserts that got is equal to the contents of the golden file with the given name.
+//
+// When updating is enabled, the file is written instead and the assertion passes.
+// On failure, the message contains a unified diff from the golden file to got.
+func Match[T Text](name string, got T) (bool, string) {
+	return match(name, []byte(got), nil)
+}
+
+// MatchJSON asserts that got is JSON equivalent to the contents of the golden file with the given name.
+//
+// Both got and the golden file are normalized before comparison:
+// object keys are sorted and the documents are indented with two spaces.
+// Updating writes the normalized form of got.
+func MatchJSON[T Text](name string, got T) (bool, string) {
+	return match(name, []byte(got), normalizeJSON)
+}
+
+// Updating returns true when golden files are being updated rather than compared to.
+func Updating() bool {
+	return update.Enabled(UpdateEnv)
+}
+
+// Path returns the path of the golden file with the given name.
+func Path(name string) string {
+	return filepath.Join(Dir, name+".golden")
+}
+
+func match(name string, got []byte, normalize func([]byte) ([]byte, error)) (bool, string) {
+	path := Path(name)
+
+	if normalize != nil {
+		var err error
+		got, err = normalize(got)
+		if err != nil {
+			return false, fmt.Sprintf("cannot normalize value for golden file %s: %s", path, err)
+		}
+	}
+
+	if Updating() {
+		if err := write(path, got); err != nil {
+			return false, fmt.Sprintf("cannot update golden file %s: %s", path, err)
+		}
+		return true, ""
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Sprintf("golden file %s does not exist, run the tests with -update to create it", path)
+	}
+	if err != nil {
+		return false, fmt.Sprintf("cannot read golden file %s: %s", path, err)
+	}
+
+	if normalize != nil {
+		want, err = normalize(want)
+		if err != nil {
+			return false, fmt.Sprintf("cannot normalize golden file %s: %s", path, err)
+		}
+	}
+
+	if bytes.Equal(got, want) {
+		return true, ""
+	}
+
+	d := diff.Unified(path, "got", string(want), string(got))
+	return false, fmt.Sprintf("got value different from golden file %s:\n%s", path, d)
+}
+
+func write(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+func normalizeJSON(doc []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
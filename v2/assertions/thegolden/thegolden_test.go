@@ -0,0 +1,188 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thegolden_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/thegolden"
+)
+
+func TestMatch(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		useGoldenDir(t, map[string]string{"page": "<p>\n  hello\n</p>\n"})
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.Match("page", "<p>\n  hello\n</p>\n"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		dir := useGoldenDir(t, map[string]string{"page": "a\nb\nc\nd\ne\nf\ng\nh\n"})
+		path := filepath.Join(dir, "page.golden")
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.Match("page", []byte("a\nb\nc\nd\nE\nf\ng\nh\n")))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				"got value different from golden file " + path + ":\n" +
+					"--- " + path + "\n" +
+					"+++ got\n" +
+					"@@ -2,7 +2,7 @@\n" +
+					" b\n c\n d\n-e\n+E\n f\n g\n h\n"))
+	})
+
+	t.Run("False/Missing", func(t *testing.T) {
+		// given
+		dir := useGoldenDir(t, nil)
+		path := filepath.Join(dir, "page.golden")
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.Match("page", "hello"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo("golden file " + path + " does not exist, run the tests with -update to create it"))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		// given
+		dir := useGoldenDir(t, map[string]string{"page": "old"})
+		t.Setenv(thegolden.UpdateEnv, "1")
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.Match("nested/page", "new"))
+
+		// then
+		content, err := os.ReadFile(filepath.Join(dir, "nested", "page.golden"))
+
+		assert.Using(t.Errorf).
+			That(errFunc.NotCalled()).
+			That(theerr.IsNil(err)).
+			That(theval.Equal(string(content), "new"))
+	})
+
+}
+
+func TestMatchJSON(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		useGoldenDir(t, map[string]string{"order": `{"id": 1, "items": ["a"]}`})
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.MatchJSON("order", `{"items":["a"],"id":1}`))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		dir := useGoldenDir(t, map[string]string{"order": `{"id": 1, "items": ["a"]}`})
+		path := filepath.Join(dir, "order.golden")
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.MatchJSON("order", `{"items":["b"],"id":1}`))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				"got value different from golden file " + path + ":\n" +
+					"--- " + path + "\n" +
+					"+++ got\n" +
+					"@@ -1,6 +1,6 @@\n" +
+					" {\n" +
+					`   "id": 1,` + "\n" +
+					`   "items": [` + "\n" +
+					`-    "a"` + "\n" +
+					`+    "b"` + "\n" +
+					"   ]\n" +
+					" }\n"))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		// given
+		dir := useGoldenDir(t, nil)
+		t.Setenv(thegolden.UpdateEnv, "1")
+
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thegolden.MatchJSON("order", `{"b":"<&>","a":1.50}`))
+
+		// then
+		content, err := os.ReadFile(filepath.Join(dir, "order.golden"))
+
+		assert.Using(t.Errorf).
+			That(errFunc.NotCalled()).
+			That(theerr.IsNil(err)).
+			That(theval.Equal(string(content), "{\n  \"a\": 1.50,\n  \"b\": \"<&>\"\n}\n"))
+	})
+
+}
+
+func useGoldenDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name+".golden"), []byte(content), 0o644)
+		assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	}
+
+	oldDir := thegolden.Dir
+	t.Cleanup(func() { thegolden.Dir = oldDir })
+	thegolden.Dir = dir
+
+	return dir
+}
@@ -0,0 +1,243 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thehttp provides reusable assertions about HTTP responses recorded with [httptest].
+//
+// The assertions can be used on a [httptest.ResponseRecorder] directly:
+//
+//	assert.Using(t.Errorf).That(thehttp.Status(rec, http.StatusOK))
+//
+// Serve drives a handler and returns a Response with the same assertions as methods:
+//
+//	resp := thehttp.Serve(handler, httptest.NewRequest("GET", "/", nil))
+//	assert.Using(t.Errorf).
+//	    That(resp.Status(http.StatusOK)).
+//	    That(resp.ContentType("text/plain"))
+//
+// Failure messages include a dump of the response, truncated to MaxDumpLength bytes.
+package thehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"reflect"
+	"strings"
+)
+
+// MaxDumpLength is the maximum number of bytes of a response dump included in a failure message.
+var MaxDumpLength = 1024
+
+// A Response is the result of serving a request with a handler.
+type Response struct {
+	rec *httptest.ResponseRecorder
+}
+
+// Serve passes req to h and records the response.
+func Serve(h http.Handler, req *http.Request) *Response {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return &Response{rec}
+}
+
+// Recorder returns the recorder the response was written to.
+func (r *Response) Recorder() *httptest.ResponseRecorder { return r.rec }
+
+// Status asserts that the response has the wanted status code.
+func (r *Response) Status(want int) (bool, string) { return Status(r.rec, want) }
+
+// HeaderEquals asserts that the response header key has the wanted value.
+func (r *Response) HeaderEquals(key, want string) (bool, string) {
+	return HeaderEquals(r.rec, key, want)
+}
+
+// HeaderContains asserts that the response header key contains substr.
+func (r *Response) HeaderContains(key, substr string) (bool, string) {
+	return HeaderContains(r.rec, key, substr)
+}
+
+// ContentType asserts that the response has the wanted media type.
+func (r *Response) ContentType(want string) (bool, string) { return ContentType(r.rec, want) }
+
+// BodyEquals asserts that the response body is exactly want.
+func (r *Response) BodyEquals(want string) (bool, string) { return BodyEquals(r.rec, want) }
+
+// BodyJSONEquals asserts that the response body is JSON semantically equal to want.
+func (r *Response) BodyJSONEquals(want string) (bool, string) { return BodyJSONEquals(r.rec, want) }
+
+// Redirects asserts that the response redirects to the wanted location.
+func (r *Response) Redirects(to string) (bool, string) { return Redirects(r.rec, to) }
+
+// Cookie asserts that the response sets the named cookie to the wanted value.
+func (r *Response) Cookie(name, want string) (bool, string) { return Cookie(r.rec, name, want) }
+
+// Status asserts that the recorded response has the wanted status code.
+func Status(rec *httptest.ResponseRecorder, want int) (bool, string) {
+	got := rec.Result().StatusCode
+	if got == want {
+		return true, ""
+	}
+	return false, failure(rec, "got status %d, not %d", got, want)
+}
+
+// HeaderEquals asserts that the first value of the recorded response header key is want.
+func HeaderEquals(rec *httptest.ResponseRecorder, key, want string) (bool, string) {
+	h := rec.Result().Header
+	if _, ok := h[http.CanonicalHeaderKey(key)]; !ok {
+		return false, failure(rec, "header %s is missing, wanted %q", key, want)
+	}
+
+	got := h.Get(key)
+	if got == want {
+		return true, ""
+	}
+	return false, failure(rec, "got header %s %q, not %q", key, got, want)
+}
+
+// HeaderContains asserts that one of the values of the recorded response header key contains substr.
+func HeaderContains(rec *httptest.ResponseRecorder, key, substr string) (bool, string) {
+	got := rec.Result().Header.Values(key)
+	for _, v := range got {
+		if strings.Contains(v, substr) {
+			return true, ""
+		}
+	}
+	return false, failure(rec, "header %s %q does not contain %q", key, got, substr)
+}
+
+// ContentType asserts that the recorded response has the wanted media type.
+//
+// When want has no parameters, the parameters of the actual content type are ignored.
+// Otherwise they must be equal.
+func ContentType(rec *httptest.ResponseRecorder, want string) (bool, string) {
+	wantType, wantParams, err := mime.ParseMediaType(want)
+	if err != nil {
+		return false, fmt.Sprintf("invalid wanted content type %q: %s", want, err)
+	}
+
+	got := rec.Result().Header.Get("Content-Type")
+	gotType, gotParams, err := mime.ParseMediaType(got)
+	if err != nil {
+		return false, failure(rec, "got invalid content type %q, not %q", got, want)
+	}
+
+	if gotType != wantType {
+		return false, failure(rec, "got content type %q, not %q", got, want)
+	}
+
+	if len(wantParams) > 0 && !reflect.DeepEqual(gotParams, wantParams) {
+		return false, failure(rec, "got content type %q, not %q", got, want)
+	}
+
+	return true, ""
+}
+
+// BodyEquals asserts that the recorded response body is exactly want.
+func BodyEquals(rec *httptest.ResponseRecorder, want string) (bool, string) {
+	got := string(body(rec))
+	if got == want {
+		return true, ""
+	}
+	return false, failure(rec, "got body %q, not %q", got, want)
+}
+
+// BodyJSONEquals asserts that the recorded response body is JSON semantically equal to want.
+//
+// Object key order and whitespace do not matter.
+func BodyJSONEquals(rec *httptest.ResponseRecorder, want string) (bool, string) {
+	var wantVal any
+	if err := json.Unmarshal([]byte(want), &wantVal); err != nil {
+		return false, fmt.Sprintf("invalid wanted JSON %q: %s", want, err)
+	}
+
+	var gotVal any
+	if err := json.Unmarshal(body(rec), &gotVal); err != nil {
+		return false, failure(rec, "got body that is not valid JSON: %s", err)
+	}
+
+	if reflect.DeepEqual(gotVal, wantVal) {
+		return true, ""
+	}
+	return false, failure(rec, "got JSON body %s, not %s", body(rec), want)
+}
+
+// Redirects asserts that the recorded response is a redirect to the wanted location.
+func Redirects(rec *httptest.ResponseRecorder, to string) (bool, string) {
+	res := rec.Result()
+	if res.StatusCode < 300 || res.StatusCode > 399 {
+		return false, failure(rec, "got status %d, not a redirect to %q", res.StatusCode, to)
+	}
+
+	got := res.Header.Get("Location")
+	if got == to {
+		return true, ""
+	}
+	return false, failure(rec, "got redirect to %q, not %q", got, to)
+}
+
+// Cookie asserts that the recorded response sets the named cookie to the wanted value.
+func Cookie(rec *httptest.ResponseRecorder, name, want string) (bool, string) {
+	for _, c := range rec.Result().Cookies() {
+		if c.Name != name {
+			continue
+		}
+		if c.Value == want {
+			return true, ""
+		}
+		return false, failure(rec, "got cookie %s=%q, not %q", name, c.Value, want)
+	}
+	return false, failure(rec, "cookie %s is not set, wanted %q", name, want)
+}
+
+func body(rec *httptest.ResponseRecorder) []byte {
+	if rec.Body == nil {
+		return nil
+	}
+	return rec.Body.Bytes()
+}
+
+func failure(rec *httptest.ResponseRecorder, msgFmt string, args ...any) string {
+	msg := fmt.Sprintf(msgFmt, args...)
+	return fmt.Sprintf("%s\n\nresponse:\n%s", msg, dump(rec))
+}
+
+func dump(rec *httptest.ResponseRecorder) string {
+	b := body(rec)
+	res := *rec.Result()
+	res.Body = io.NopCloser(bytes.NewReader(b))
+	res.ContentLength = int64(len(b))
+
+	out, err := httputil.DumpResponse(&res, true)
+	if err != nil {
+		return fmt.Sprintf("<cannot dump response: %s>", err)
+	}
+
+	if len(out) <= MaxDumpLength {
+		return string(out)
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", out[:MaxDumpLength], len(out)-MaxDumpLength)
+}
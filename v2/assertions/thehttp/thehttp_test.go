@@ -0,0 +1,420 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thehttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thehttp"
+)
+
+func TestStatus(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Status(http.StatusOK))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusNotFound, nil, "nope")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Status(http.StatusOK))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				"got status 404, not 200\n\n" +
+					"response:\n" +
+					"HTTP/1.1 404 Not Found\r\nContent-Length: 4\r\n\r\nnope"))
+	})
+
+	t.Run("False/Truncated", func(t *testing.T) {
+		// given
+		defer func(n int) { thehttp.MaxDumpLength = n }(thehttp.MaxDumpLength)
+		thehttp.MaxDumpLength = 8
+
+		var errFunc assertiontesting.ErrFunc
+		rec := serve(http.StatusNotFound, nil, "nope").Recorder()
+
+		// when
+		assert.Using(errFunc.Record).That(thehttp.Status(rec, http.StatusOK))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo("got status 404, not 200\n\nresponse:\nHTTP/1.1... (41 more bytes)"))
+	})
+
+}
+
+func TestHeaderEquals(t *testing.T) {
+
+	header := http.Header{"X-Request-Id": {"abc"}}
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.HeaderEquals("x-request-id", "abc"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False/Different", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.HeaderEquals("X-Request-Id", "def"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`got header X-Request-Id "abc", not "def"`,
+				"HTTP/1.1 200 OK\r\nX-Request-Id: abc\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+	t.Run("False/Missing", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.HeaderEquals("X-Request-Id", ""))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`header X-Request-Id is missing, wanted ""`,
+				"HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+}
+
+func TestHeaderContains(t *testing.T) {
+
+	header := http.Header{"Vary": {"Accept", "Accept-Encoding"}}
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.HeaderContains("Vary", "Encoding"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.HeaderContains("Vary", "Cookie"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`header Vary ["Accept" "Accept-Encoding"] does not contain "Cookie"`,
+				"HTTP/1.1 200 OK\r\nVary: Accept\r\nVary: Accept-Encoding\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+}
+
+func TestContentType(t *testing.T) {
+
+	header := http.Header{"Content-Type": {"text/plain; charset=utf-8"}}
+
+	okCases := []string{
+		"text/plain",
+		"text/plain; charset=utf-8",
+		"Text/Plain; charset=\"utf-8\"",
+	}
+
+	for _, want := range okCases {
+		want := want
+
+		t.Run("True/"+want, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+			resp := serve(http.StatusOK, header, "")
+
+			// when
+			assert.Using(errFunc.Record).That(resp.ContentType(want))
+
+			// then
+			assert.Using(t.Errorf).That(errFunc.NotCalled())
+		})
+	}
+
+	oopsCases := []string{
+		"text/html",
+		"text/plain; charset=latin1",
+	}
+
+	for _, want := range oopsCases {
+		want := want
+
+		t.Run("False/"+want, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+			resp := serve(http.StatusOK, header, "")
+
+			// when
+			assert.Using(errFunc.Record).That(resp.ContentType(want))
+
+			// then
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(withDump(
+					fmt.Sprintf("got content type %q, not %q", "text/plain; charset=utf-8", want),
+					"HTTP/1.1 200 OK\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Length: 0\r\n\r\n")))
+		})
+	}
+
+}
+
+func TestBodyEquals(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, "hello")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.BodyEquals("hello"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, "hello")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.BodyEquals("bye"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`got body "hello", not "bye"`,
+				"HTTP/1.1 200 OK\r\nContent-Length: 5\r\n\r\nhello")))
+	})
+
+}
+
+func TestBodyJSONEquals(t *testing.T) {
+
+	body := `{"b": [1, 2], "a": "x"}`
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, body)
+
+		// when
+		assert.Using(errFunc.Record).That(resp.BodyJSONEquals(`{"a":"x","b":[1,2]}`))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, body)
+
+		// when
+		assert.Using(errFunc.Record).That(resp.BodyJSONEquals(`{"a":"x","b":[2,1]}`))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`got JSON body {"b": [1, 2], "a": "x"}, not {"a":"x","b":[2,1]}`,
+				"HTTP/1.1 200 OK\r\nContent-Length: 23\r\n\r\n"+body)))
+	})
+
+	t.Run("False/Invalid", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, "{")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.BodyJSONEquals(`{}`))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				"got body that is not valid JSON: unexpected end of JSON input",
+				"HTTP/1.1 200 OK\r\nContent-Length: 1\r\n\r\n{")))
+	})
+
+}
+
+func TestRedirects(t *testing.T) {
+
+	header := http.Header{"Location": {"/login"}}
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusFound, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Redirects("/login"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False/Location", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusFound, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Redirects("/home"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`got redirect to "/login", not "/home"`,
+				"HTTP/1.1 302 Found\r\nLocation: /login\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+	t.Run("False/Status", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Redirects("/login"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`got status 200, not a redirect to "/login"`,
+				"HTTP/1.1 200 OK\r\nLocation: /login\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+}
+
+func TestCookie(t *testing.T) {
+
+	header := http.Header{"Set-Cookie": {"session=abc; Path=/"}}
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Cookie("session", "abc"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False/Value", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, header, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Cookie("session", "def"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`got cookie session="abc", not "def"`,
+				"HTTP/1.1 200 OK\r\nSet-Cookie: session=abc; Path=/\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+	t.Run("False/Missing", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		resp := serve(http.StatusOK, nil, "")
+
+		// when
+		assert.Using(errFunc.Record).That(resp.Cookie("session", "abc"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(withDump(
+				`cookie session is not set, wanted "abc"`,
+				"HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")))
+	})
+
+}
+
+func serve(status int, header http.Header, body string) *thehttp.Response {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, vs := range header {
+			w.Header()[k] = vs
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	})
+	return thehttp.Serve(h, httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func withDump(msg, dump string) string {
+	return strings.Join([]string{msg, "", "response:", dump}, "\n")
+}
@@ -0,0 +1,218 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thejson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HasPath asserts that doc has a value at path.
+//
+// A path starts with $, which denotes the whole document.
+// It is followed by any number of steps: .key, ["key"] or ['key'] selects a key of an object,
+// and [n] selects an element of an array.
+func HasPath[D Text](doc D, path string) (bool, string) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	v, err := decode(doc)
+	if err != nil {
+		return false, fmt.Sprintf("got invalid JSON %q: %s", doc, err)
+	}
+
+	if _, err := lookup(v, steps); err != nil {
+		return false, fmt.Sprintf("no value at path %s: %s", path, err)
+	}
+	return true, ""
+}
+
+// PathEquals asserts that the value at path in doc is equal to want.
+//
+// The wanted value is converted to JSON with [json.Marshal] before comparison.
+// For the syntax of paths see HasPath.
+func PathEquals[D Text](doc D, path string, want any) (bool, string) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	wantVal, err := normalize(want)
+	if err != nil {
+		return false, fmt.Sprintf("cannot convert wanted value %#v to JSON: %s", want, err)
+	}
+
+	v, err := decode(doc)
+	if err != nil {
+		return false, fmt.Sprintf("got invalid JSON %q: %s", doc, err)
+	}
+
+	got, err := lookup(v, steps)
+	if err != nil {
+		return false, fmt.Sprintf("no value at path %s: %s", path, err)
+	}
+
+	diffs := compare(path, got, wantVal, false, nil)
+	if len(diffs) == 0 {
+		return true, ""
+	}
+	return false, strings.Join(diffs, "; ")
+}
+
+type step struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func parsePath(path string) ([]step, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid path %q: must start with $", path)
+	}
+
+	var steps []step
+	rest := path[1:]
+	for rest != "" {
+		var (
+			s   step
+			err error
+		)
+		s, rest, err = parseStep(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %s", path, err)
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+func parseStep(rest string) (step, string, error) {
+	switch rest[0] {
+
+	case '.':
+		end := strings.IndexAny(rest[1:], ".[")
+		if end < 0 {
+			end = len(rest) - 1
+		}
+		key := rest[1 : end+1]
+		if key == "" {
+			return step{}, "", fmt.Errorf("empty key at %q", rest)
+		}
+		return step{key: key}, rest[end+1:], nil
+
+	case '[':
+		end := closingBracket(rest)
+		if end < 0 {
+			return step{}, "", fmt.Errorf("unclosed bracket at %q", rest)
+		}
+		inner := rest[1:end]
+
+		if strings.HasPrefix(inner, `"`) {
+			key, err := strconv.Unquote(inner)
+			if err != nil {
+				return step{}, "", fmt.Errorf("invalid key %s", inner)
+			}
+			return step{key: key}, rest[end+1:], nil
+		}
+
+		if len(inner) >= 2 && strings.HasPrefix(inner, "'") && strings.HasSuffix(inner, "'") {
+			return step{key: inner[1 : len(inner)-1]}, rest[end+1:], nil
+		}
+
+		i, err := strconv.Atoi(inner)
+		if err != nil || i < 0 {
+			return step{}, "", fmt.Errorf("invalid index %q", inner)
+		}
+		return step{index: i, isIndex: true}, rest[end+1:], nil
+
+	default:
+		return step{}, "", fmt.Errorf("unexpected %q", rest)
+	}
+}
+
+// closingBracket returns the index of the bracket closing the one at the start of s.
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == 0 && (s[i] == '"' || s[i] == '\''):
+			quote = s[i]
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func lookup(v any, steps []step) (any, error) {
+	path := "$"
+	for _, s := range steps {
+		if s.isIndex {
+			arr, ok := v.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%s is %s, not an array", path, kind(v))
+			}
+			if s.index >= len(arr) {
+				return nil, fmt.Errorf("%s is an array of length %d", path, len(arr))
+			}
+			v, path = arr[s.index], indexPath(path, s.index)
+			continue
+		}
+
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s is %s, not an object", path, kind(v))
+		}
+		next, ok := obj[s.key]
+		if !ok {
+			return nil, fmt.Errorf("%s has no key %q", path, s.key)
+		}
+		v, path = next, keyPath(path, s.key)
+	}
+	return v, nil
+}
+
+func kind(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return "null"
+	}
+}
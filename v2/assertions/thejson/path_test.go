@@ -0,0 +1,165 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thejson_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thejson"
+)
+
+const pathDoc = `{"items": [{"id": 1, "tags": ["a"]}], "odd key": {"x": null}}`
+
+func TestHasPath(t *testing.T) {
+
+	okCases := []string{
+		`$`,
+		`$.items`,
+		`$.items[0].id`,
+		`$.items[0].tags[0]`,
+		`$["odd key"].x`,
+		`$['items'][0]`,
+	}
+
+	for _, path := range okCases {
+		path := path
+
+		t.Run("True/"+path, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thejson.HasPath(pathDoc, path))
+
+			// then
+			assert.Using(t.Errorf).That(errFunc.NotCalled())
+		})
+	}
+
+	oopsCases := []struct {
+		Path    string
+		Message string
+	}{
+		{
+			Path:    `$.items[1]`,
+			Message: `no value at path $.items[1]: $.items is an array of length 1`,
+		},
+		{
+			Path:    `$.items[0].name`,
+			Message: `no value at path $.items[0].name: $.items[0] has no key "name"`,
+		},
+		{
+			Path:    `$.items[0].id.x`,
+			Message: `no value at path $.items[0].id.x: $.items[0].id is a number, not an object`,
+		},
+		{
+			Path:    `$.items.x`,
+			Message: `no value at path $.items.x: $.items is an array, not an object`,
+		},
+		{
+			Path:    `items`,
+			Message: `invalid path "items": must start with $`,
+		},
+		{
+			Path:    `$.items[x]`,
+			Message: `invalid path "$.items[x]": invalid index "x"`,
+		},
+		{
+			Path:    `$.items[0`,
+			Message: `invalid path "$.items[0": unclosed bracket at "[0"`,
+		},
+	}
+
+	for _, tt := range oopsCases {
+		tt := tt
+
+		t.Run("False/"+tt.Path, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thejson.HasPath(pathDoc, tt.Path))
+
+			// then
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(tt.Message))
+		})
+	}
+
+}
+
+func TestPathEquals(t *testing.T) {
+
+	t.Run("True/Number", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.PathEquals(pathDoc, "$.items[0].id", 1))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("True/Slice", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.PathEquals(pathDoc, "$.items[0].tags", []string{"a"}))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.PathEquals(pathDoc, "$.items[0]", map[string]any{"id": 2}))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`$.items[0].id: got 1, not 2; $.items[0].tags: unexpected ["a"]`))
+	})
+
+	t.Run("False/Missing", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.PathEquals(pathDoc, "$.id", 1))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`no value at path $.id: $ has no key "id"`))
+	})
+
+}
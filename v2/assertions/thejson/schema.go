@@ -0,0 +1,416 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thejson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// MatchesSchema asserts that doc is valid according to a JSON Schema.
+//
+// Only a subset of JSON Schema is supported.
+// The keywords understood are:
+//
+//   - type (a single type name or an array of them),
+//   - enum and const,
+//   - properties, required and additionalProperties,
+//   - items, minItems and maxItems,
+//   - minLength, maxLength and pattern,
+//   - minimum and maximum, and
+//   - allOf, anyOf and oneOf.
+//
+// Other keywords are ignored.
+func MatchesSchema[D, S Text](doc D, schema S) (bool, string) {
+	schemaVal, err := decode(schema)
+	if err != nil {
+		return false, fmt.Sprintf("invalid JSON schema %q: %s", schema, err)
+	}
+
+	v, err := decode(doc)
+	if err != nil {
+		return false, fmt.Sprintf("got invalid JSON %q: %s", doc, err)
+	}
+
+	problems, err := validate("$", v, schemaVal, nil)
+	if err != nil {
+		return false, fmt.Sprintf("invalid JSON schema: %s", err)
+	}
+
+	if len(problems) == 0 {
+		return true, ""
+	}
+	return false, fmt.Sprintf("got JSON %s not matching schema: %s", encode(v), strings.Join(problems, "; "))
+}
+
+func validate(path string, v, schema any, problems []string) ([]string, error) {
+	if b, ok := schema.(bool); ok {
+		if !b {
+			problems = append(problems, fmt.Sprintf("%s: no value allowed", path))
+		}
+		return problems, nil
+	}
+
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("schema at %s is %s, not an object", path, kind(schema))
+	}
+
+	var err error
+	for _, check := range []func(string, any, map[string]any, []string) ([]string, error){
+		checkType,
+		checkEnum,
+		checkObject,
+		checkArray,
+		checkString,
+		checkNumber,
+		checkCombinators,
+	} {
+		problems, err = check(path, v, s, problems)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+func checkType(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	t, ok := s["type"]
+	if !ok {
+		return problems, nil
+	}
+
+	var types []string
+	switch t := t.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, name := range t {
+			name, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("type at %s is not a string", path)
+			}
+			types = append(types, name)
+		}
+	default:
+		return nil, fmt.Errorf("type at %s is %s, not a string or array", path, kind(t))
+	}
+
+	for _, name := range types {
+		if hasType(v, name) {
+			return problems, nil
+		}
+	}
+	return append(problems, fmt.Sprintf("%s: got %s, not of type %s", path, kind(v), strings.Join(types, " or "))), nil
+}
+
+func hasType(v any, name string) bool {
+	switch name {
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		r, ok := new(big.Rat).SetString(string(n))
+		return ok && r.IsInt()
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "null":
+		return v == nil
+	default:
+		return false
+	}
+}
+
+func checkEnum(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	if c, ok := s["const"]; ok && len(compare(path, v, c, false, nil)) > 0 {
+		problems = append(problems, fmt.Sprintf("%s: got %s, not %s", path, encode(v), encode(c)))
+	}
+
+	e, ok := s["enum"]
+	if !ok {
+		return problems, nil
+	}
+
+	options, ok := e.([]any)
+	if !ok {
+		return nil, fmt.Errorf("enum at %s is %s, not an array", path, kind(e))
+	}
+
+	for _, o := range options {
+		if len(compare(path, v, o, false, nil)) == 0 {
+			return problems, nil
+		}
+	}
+	return append(problems, fmt.Sprintf("%s: got %s, not one of %s", path, encode(v), encode(options))), nil
+}
+
+func checkObject(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return problems, nil
+	}
+
+	if r, ok := s["required"]; ok {
+		required, ok := r.([]any)
+		if !ok {
+			return nil, fmt.Errorf("required at %s is %s, not an array", path, kind(r))
+		}
+		for _, k := range required {
+			k, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("required at %s contains a non-string", path)
+			}
+			if _, ok := obj[k]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required key %q", path, k))
+			}
+		}
+	}
+
+	var props map[string]any
+	if p, ok := s["properties"]; ok {
+		props, ok = p.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("properties at %s is %s, not an object", path, kind(p))
+		}
+	}
+
+	additional, hasAdditional := s["additionalProperties"]
+
+	keys := maps.Keys(obj)
+	slices.Sort(keys)
+
+	var err error
+	for _, k := range keys {
+		sub, ok := props[k]
+		if !ok && !hasAdditional {
+			continue
+		}
+		if !ok {
+			sub = additional
+		}
+
+		problems, err = validate(keyPath(path, k), obj[k], sub, problems)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+func checkArray(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return problems, nil
+	}
+
+	if n, ok, err := intKeyword(s, "minItems", path); err != nil {
+		return nil, err
+	} else if ok && len(arr) < n {
+		problems = append(problems, fmt.Sprintf("%s: got array of length %d, less than %d", path, len(arr), n))
+	}
+
+	if n, ok, err := intKeyword(s, "maxItems", path); err != nil {
+		return nil, err
+	} else if ok && len(arr) > n {
+		problems = append(problems, fmt.Sprintf("%s: got array of length %d, more than %d", path, len(arr), n))
+	}
+
+	items, ok := s["items"]
+	if !ok {
+		return problems, nil
+	}
+
+	var err error
+	for i, elem := range arr {
+		problems, err = validate(indexPath(path, i), elem, items, problems)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+func checkString(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	str, ok := v.(string)
+	if !ok {
+		return problems, nil
+	}
+	length := utf8.RuneCountInString(str)
+
+	if n, ok, err := intKeyword(s, "minLength", path); err != nil {
+		return nil, err
+	} else if ok && length < n {
+		problems = append(problems, fmt.Sprintf("%s: got string of length %d, less than %d", path, length, n))
+	}
+
+	if n, ok, err := intKeyword(s, "maxLength", path); err != nil {
+		return nil, err
+	} else if ok && length > n {
+		problems = append(problems, fmt.Sprintf("%s: got string of length %d, more than %d", path, length, n))
+	}
+
+	p, ok := s["pattern"]
+	if !ok {
+		return problems, nil
+	}
+
+	pattern, ok := p.(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern at %s is %s, not a string", path, kind(p))
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("pattern at %s: %s", path, err)
+	}
+
+	if !re.MatchString(str) {
+		problems = append(problems, fmt.Sprintf("%s: got %q, not matching pattern %q", path, str, pattern))
+	}
+	return problems, nil
+}
+
+func checkNumber(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return problems, nil
+	}
+
+	got, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return problems, nil
+	}
+
+	if limit, ok, err := ratKeyword(s, "minimum", path); err != nil {
+		return nil, err
+	} else if ok && got.Cmp(limit) < 0 {
+		problems = append(problems, fmt.Sprintf("%s: got %s, less than %s", path, n, s["minimum"]))
+	}
+
+	if limit, ok, err := ratKeyword(s, "maximum", path); err != nil {
+		return nil, err
+	} else if ok && got.Cmp(limit) > 0 {
+		problems = append(problems, fmt.Sprintf("%s: got %s, more than %s", path, n, s["maximum"]))
+	}
+
+	return problems, nil
+}
+
+func checkCombinators(path string, v any, s map[string]any, problems []string) ([]string, error) {
+	if sub, ok := s["allOf"]; ok {
+		schemas, ok := sub.([]any)
+		if !ok {
+			return nil, fmt.Errorf("allOf at %s is %s, not an array", path, kind(sub))
+		}
+
+		var err error
+		for _, schema := range schemas {
+			problems, err = validate(path, v, schema, problems)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		sub, ok := s[keyword]
+		if !ok {
+			continue
+		}
+
+		schemas, ok := sub.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s at %s is %s, not an array", keyword, path, kind(sub))
+		}
+
+		matches := 0
+		for _, schema := range schemas {
+			p, err := validate(path, v, schema, nil)
+			if err != nil {
+				return nil, err
+			}
+			if len(p) == 0 {
+				matches++
+			}
+		}
+
+		switch {
+		case matches == 0:
+			problems = append(problems, fmt.Sprintf("%s: got %s, matching none of %s", path, encode(v), keyword))
+		case keyword == "oneOf" && matches > 1:
+			problems = append(problems, fmt.Sprintf("%s: got %s, matching %d schemas of oneOf", path, encode(v), matches))
+		}
+	}
+
+	return problems, nil
+}
+
+func intKeyword(s map[string]any, keyword, path string) (int, bool, error) {
+	r, ok, err := ratKeyword(s, keyword, path)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false, fmt.Errorf("%s at %s is not an integer", keyword, path)
+	}
+	return int(r.Num().Int64()), true, nil
+}
+
+func ratKeyword(s map[string]any, keyword, path string) (*big.Rat, bool, error) {
+	v, ok := s[keyword]
+	if !ok {
+		return nil, false, nil
+	}
+
+	n, ok := v.(json.Number)
+	if !ok {
+		return nil, false, fmt.Errorf("%s at %s is %s, not a number", keyword, path, kind(v))
+	}
+
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return nil, false, fmt.Errorf("%s at %s is not a valid number", keyword, path)
+	}
+	return r, true, nil
+}
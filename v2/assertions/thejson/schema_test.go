@@ -0,0 +1,187 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thejson_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thejson"
+)
+
+const orderSchema = `{
+	"type": "object",
+	"required": ["id", "items"],
+	"additionalProperties": false,
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"status": {"enum": ["new", "paid"]},
+		"note": {"type": ["string", "null"], "maxLength": 5},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"type": "object",
+				"properties": {"sku": {"type": "string", "pattern": "^[A-Z]+-[0-9]+$"}}
+			}
+		}
+	}
+}`
+
+func TestMatchesSchema(t *testing.T) {
+
+	okCases := []struct {
+		Name string
+		Doc  string
+	}{
+		{
+			Name: "Minimal",
+			Doc:  `{"id": 1, "items": [{}]}`,
+		},
+		{
+			Name: "Full",
+			Doc:  `{"id": 2, "status": "paid", "note": null, "items": [{"sku": "AB-1"}, {"sku": "C-22"}]}`,
+		},
+	}
+
+	for _, tt := range okCases {
+		tt := tt
+
+		t.Run("True/"+tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thejson.MatchesSchema(tt.Doc, orderSchema))
+
+			// then
+			assert.Using(t.Errorf).That(errFunc.NotCalled())
+		})
+	}
+
+	oopsCases := []struct {
+		Name    string
+		Doc     string
+		Message string
+	}{
+		{
+			Name:    "Type",
+			Doc:     `[]`,
+			Message: `got JSON [] not matching schema: $: got an array, not of type object`,
+		},
+		{
+			Name:    "Required",
+			Doc:     `{"id": 1}`,
+			Message: `got JSON {"id":1} not matching schema: $: missing required key "items"`,
+		},
+		{
+			Name:    "AdditionalProperties",
+			Doc:     `{"id": 1, "items": [{}], "x": 1}`,
+			Message: `got JSON {"id":1,"items":[{}],"x":1} not matching schema: $.x: no value allowed`,
+		},
+		{
+			Name:    "Integer",
+			Doc:     `{"id": 1.5, "items": [{}]}`,
+			Message: `got JSON {"id":1.5,"items":[{}]} not matching schema: $.id: got a number, not of type integer`,
+		},
+		{
+			Name:    "Minimum",
+			Doc:     `{"id": 0, "items": [{}]}`,
+			Message: `got JSON {"id":0,"items":[{}]} not matching schema: $.id: got 0, less than 1`,
+		},
+		{
+			Name:    "Enum",
+			Doc:     `{"id": 1, "status": "lost", "items": [{}]}`,
+			Message: `got JSON {"id":1,"items":[{}],"status":"lost"} not matching schema: $.status: got "lost", not one of ["new","paid"]`,
+		},
+		{
+			Name:    "MaxLength",
+			Doc:     `{"id": 1, "note": "żółwie", "items": [{}]}`,
+			Message: `got JSON {"id":1,"items":[{}],"note":"żółwie"} not matching schema: $.note: got string of length 6, more than 5`,
+		},
+		{
+			Name:    "MinItems",
+			Doc:     `{"id": 1, "items": []}`,
+			Message: `got JSON {"id":1,"items":[]} not matching schema: $.items: got array of length 0, less than 1`,
+		},
+		{
+			Name: "Pattern",
+			Doc:  `{"id": 1, "items": [{"sku": "AB-1"}, {"sku": "ab"}]}`,
+			Message: `got JSON {"id":1,"items":[{"sku":"AB-1"},{"sku":"ab"}]} not matching schema: ` +
+				`$.items[1].sku: got "ab", not matching pattern "^[A-Z]+-[0-9]+$"`,
+		},
+		{
+			Name: "Many",
+			Doc:  `{"id": "1", "items": 3}`,
+			Message: `got JSON {"id":"1","items":3} not matching schema: ` +
+				`$.id: got a string, not of type integer; $.items: got a number, not of type array`,
+		},
+	}
+
+	for _, tt := range oopsCases {
+		tt := tt
+
+		t.Run("False/"+tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thejson.MatchesSchema(tt.Doc, orderSchema))
+
+			// then
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(tt.Message))
+		})
+	}
+
+	t.Run("False/OneOf", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		schema := `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.MatchesSchema(`1`, schema))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`got JSON 1 not matching schema: $: got 1, matching 2 schemas of oneOf`))
+	})
+
+	t.Run("False/InvalidSchema", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.MatchesSchema(`1`, `{"minimum": "0"}`))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`invalid JSON schema: minimum at $ is a string, not a number`))
+	})
+
+}
@@ -0,0 +1,229 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thejson provides reusable assertions about JSON documents.
+//
+// Documents are compared by their decoded structure rather than their text.
+// Object key order and whitespace do not matter and numbers are compared by value.
+// Differences are reported by their path in the document, like $.items[0].id.
+package thejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Text is a JSON document in textual form.
+type Text interface{ ~[]byte | ~string }
+
+// Valid asserts that doc is a valid JSON document.
+func Valid[D Text](doc D) (bool, string) {
+	if _, err := decode(doc); err != nil {
+		return false, fmt.Sprintf("got invalid JSON %q: %s", doc, err)
+	}
+	return true, ""
+}
+
+// Equal asserts that an actual JSON document is semantically equal to an expected one.
+func Equal[G, W Text](got G, want W) (bool, string) {
+	return compareDocs(got, want, false)
+}
+
+// Subset asserts that an actual JSON document contains at least the contents of an expected one.
+//
+// Objects in got may have keys that are absent in want.
+// Arrays must be of the same length and their elements are compared the same way.
+// All other values must be equal.
+func Subset[G, W Text](got G, want W) (bool, string) {
+	return compareDocs(got, want, true)
+}
+
+func compareDocs[G, W Text](got G, want W, subset bool) (bool, string) {
+	wantVal, err := decode(want)
+	if err != nil {
+		return false, fmt.Sprintf("invalid wanted JSON %q: %s", want, err)
+	}
+
+	gotVal, err := decode(got)
+	if err != nil {
+		return false, fmt.Sprintf("got invalid JSON %q: %s", got, err)
+	}
+
+	diffs := compare("$", gotVal, wantVal, subset, nil)
+	if len(diffs) == 0 {
+		return true, ""
+	}
+
+	verb := "not"
+	if subset {
+		verb = "not a superset of"
+	}
+	msg := fmt.Sprintf(
+		"got JSON %s, %s %s: %s",
+		encode(gotVal), verb, encode(wantVal), strings.Join(diffs, "; "))
+	return false, msg
+}
+
+func compare(path string, got, want any, subset bool, diffs []string) []string {
+	switch want := want.(type) {
+
+	case map[string]any:
+		gotObj, ok := got.(map[string]any)
+		if !ok {
+			return append(diffs, mismatch(path, got, want))
+		}
+
+		keys := maps.Keys(want)
+		slices.Sort(keys)
+		for _, k := range keys {
+			gotV, ok := gotObj[k]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("%s: missing, want %s", keyPath(path, k), encode(want[k])))
+				continue
+			}
+			diffs = compare(keyPath(path, k), gotV, want[k], subset, diffs)
+		}
+
+		if subset {
+			return diffs
+		}
+
+		keys = maps.Keys(gotObj)
+		slices.Sort(keys)
+		for _, k := range keys {
+			if _, ok := want[k]; !ok {
+				diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", keyPath(path, k), encode(gotObj[k])))
+			}
+		}
+		return diffs
+
+	case []any:
+		gotArr, ok := got.([]any)
+		if !ok {
+			return append(diffs, mismatch(path, got, want))
+		}
+
+		if len(gotArr) != len(want) {
+			return append(diffs, fmt.Sprintf(
+				"%s: got array of length %d, not %d",
+				path, len(gotArr), len(want)))
+		}
+
+		for i := range want {
+			diffs = compare(indexPath(path, i), gotArr[i], want[i], subset, diffs)
+		}
+		return diffs
+
+	case json.Number:
+		gotNum, ok := got.(json.Number)
+		if !ok || !numbersEqual(gotNum, want) {
+			return append(diffs, mismatch(path, got, want))
+		}
+		return diffs
+
+	default:
+		if got != want {
+			return append(diffs, mismatch(path, got, want))
+		}
+		return diffs
+	}
+}
+
+func mismatch(path string, got, want any) string {
+	return fmt.Sprintf("%s: got %s, not %s", path, encode(got), encode(want))
+}
+
+func numbersEqual(a, b json.Number) bool {
+	x, okX := new(big.Rat).SetString(string(a))
+	y, okY := new(big.Rat).SetString(string(b))
+	if !okX || !okY {
+		return a == b
+	}
+	return x.Cmp(y) == 0
+}
+
+func keyPath(path, key string) string {
+	if isIdent(key) {
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func decode[D Text](doc D) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(doc)))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+// normalize converts a Go value to the form produced by decode.
+func normalize(v any) (any, error) {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(doc)
+}
+
+func encode(v any) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(out)
+}
@@ -0,0 +1,200 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thejson_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thejson"
+)
+
+func TestValid(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.Valid(`{"a": [1, 2]}`))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.Valid([]byte(`{"a": }`)))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`got invalid JSON "{\"a\": }": invalid character '}' looking for beginning of value`))
+	})
+
+	t.Run("False/TrailingData", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.Valid(`{} {}`))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`got invalid JSON "{} {}": unexpected data after top-level value`))
+	})
+
+}
+
+func TestEqual(t *testing.T) {
+
+	okCases := []struct {
+		Name      string
+		Got, Want string
+	}{
+		{Name: "Null", Got: `null`, Want: `null`},
+		{Name: "KeyOrder", Got: `{"a": 1, "b": 2}`, Want: `{"b":2,"a":1}`},
+		{Name: "Numbers", Got: `[1, 1.0, 10]`, Want: `[1.00, 1, 1e1]`},
+		{Name: "Nested", Got: `{"a": [{"b": true}]}`, Want: `{"a": [{"b": true}]}`},
+	}
+
+	for _, tt := range okCases {
+		tt := tt
+
+		t.Run("True/"+tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thejson.Equal(tt.Got, tt.Want))
+
+			// then
+			assert.Using(t.Errorf).That(errFunc.NotCalled())
+		})
+	}
+
+	oopsCases := []struct {
+		Name      string
+		Got, Want string
+		Message   string
+	}{
+		{
+			Name:    "Value",
+			Got:     `{"a": 1}`,
+			Want:    `{"a": 2}`,
+			Message: `got JSON {"a":1}, not {"a":2}: $.a: got 1, not 2`,
+		},
+		{
+			Name:    "Type",
+			Got:     `{"a": "1"}`,
+			Want:    `{"a": 1}`,
+			Message: `got JSON {"a":"1"}, not {"a":1}: $.a: got "1", not 1`,
+		},
+		{
+			Name:    "MissingAndUnexpected",
+			Got:     `{"a": 1, "c d": 3}`,
+			Want:    `{"a": 1, "b": 2}`,
+			Message: `got JSON {"a":1,"c d":3}, not {"a":1,"b":2}: $.b: missing, want 2; $["c d"]: unexpected 3`,
+		},
+		{
+			Name:    "ArrayLength",
+			Got:     `{"items": [1]}`,
+			Want:    `{"items": [1, 2]}`,
+			Message: `got JSON {"items":[1]}, not {"items":[1,2]}: $.items: got array of length 1, not 2`,
+		},
+		{
+			Name:    "Nested",
+			Got:     `{"items": [{"id": 1}, {"id": 2}]}`,
+			Want:    `{"items": [{"id": 1}, {"id": 3}]}`,
+			Message: `got JSON {"items":[{"id":1},{"id":2}]}, not {"items":[{"id":1},{"id":3}]}: $.items[1].id: got 2, not 3`,
+		},
+		{
+			Name:    "InvalidGot",
+			Got:     `{`,
+			Want:    `{}`,
+			Message: `got invalid JSON "{": unexpected EOF`,
+		},
+		{
+			Name:    "InvalidWant",
+			Got:     `{}`,
+			Want:    `}`,
+			Message: `invalid wanted JSON "}": invalid character '}' looking for beginning of value`,
+		},
+	}
+
+	for _, tt := range oopsCases {
+		tt := tt
+
+		t.Run("False/"+tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thejson.Equal([]byte(tt.Got), tt.Want))
+
+			// then
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(tt.Message))
+		})
+	}
+
+}
+
+func TestSubset(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		got := `{"id": 7, "items": [{"id": 1, "name": "x"}], "extra": null}`
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.Subset(got, `{"items": [{"id": 1}]}`))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		got := `{"id": 7, "items": [{"name": "x"}]}`
+
+		// when
+		assert.Using(errFunc.Record).That(thejson.Subset(got, `{"id": 8, "items": [{"id": 1}]}`))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				`got JSON {"id":7,"items":[{"name":"x"}]}, not a superset of {"id":8,"items":[{"id":1}]}: ` +
+					`$.id: got 7, not 8; $.items[0].id: missing, want 1`))
+	})
+
+}
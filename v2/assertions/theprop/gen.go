@@ -0,0 +1,301 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theprop
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// A Gen generates random values of type T for checking properties.
+//
+// The values a Gen produces know how to shrink themselves.
+// When a property fails, the failing value is shrunk towards a simpler one that still fails.
+type Gen[T any] struct {
+	gen func(r *rand.Rand, size int) sample[T]
+}
+
+// A sample is a generated value together with the values it can shrink to.
+type sample[T any] struct {
+	value   T
+	shrinks func() []sample[T]
+}
+
+func noShrinks[T any]() []sample[T] { return nil }
+
+// Generate produces a single value using r.
+//
+// The size limits how big the value can be, like the length of a slice or the magnitude of a number.
+func (g Gen[T]) Generate(r *rand.Rand, size int) T {
+	return g.gen(r, size).value
+}
+
+// FromFunc creates a Gen that produces values using f.
+//
+// The values it produces do not shrink.
+func FromFunc[T any](f func(r *rand.Rand, size int) T) Gen[T] {
+	return Gen[T]{func(r *rand.Rand, size int) sample[T] {
+		return sample[T]{f(r, size), noShrinks[T]}
+	}}
+}
+
+// Just creates a Gen that always produces v.
+func Just[T any](v T) Gen[T] {
+	return Gen[T]{func(*rand.Rand, int) sample[T] {
+		return sample[T]{v, noShrinks[T]}
+	}}
+}
+
+// Bool creates a Gen of booleans.
+//
+// True shrinks to false.
+func Bool() Gen[bool] {
+	return Gen[bool]{func(r *rand.Rand, _ int) sample[bool] {
+		return boolSample(r.Intn(2) == 1)
+	}}
+}
+
+func boolSample(b bool) sample[bool] {
+	return sample[bool]{b, func() []sample[bool] {
+		if b {
+			return []sample[bool]{boolSample(false)}
+		}
+		return nil
+	}}
+}
+
+// Int creates a Gen of integers between -size and size.
+//
+// The integers shrink towards zero.
+func Int() Gen[int] {
+	return Gen[int]{func(r *rand.Rand, size int) sample[int] {
+		return intSample(r.Intn(2*size+1)-size, 0)
+	}}
+}
+
+// IntRange creates a Gen of integers between lo and hi, inclusive.
+//
+// The integers shrink towards the one closest to zero.
+// IntRange panics when lo > hi.
+func IntRange(lo, hi int) Gen[int] {
+	if lo > hi {
+		panic(fmt.Sprintf("theprop.IntRange: empty range [%d, %d]", lo, hi))
+	}
+
+	target := 0
+	switch {
+	case lo > 0:
+		target = lo
+	case hi < 0:
+		target = hi
+	}
+
+	span := uint64(hi) - uint64(lo)
+	return Gen[int]{func(r *rand.Rand, _ int) sample[int] {
+		offset := r.Uint64()
+		if span+1 != 0 {
+			offset %= span + 1
+		}
+		return intSample(int(uint64(lo)+offset), target)
+	}}
+}
+
+func intSample(n, target int) sample[int] {
+	return sample[int]{n, func() []sample[int] {
+		var out []sample[int]
+		for d := n - target; d != 0; d /= 2 {
+			out = append(out, intSample(n-d, target))
+		}
+		return out
+	}}
+}
+
+// Rune creates a Gen of runes.
+//
+// Most runes are ASCII letters and punctuation, but some come from other scripts.
+// They shrink to 'a'.
+func Rune() Gen[rune] {
+	return Gen[rune]{func(r *rand.Rand, _ int) sample[rune] {
+		var c rune
+		switch p := r.Intn(10); {
+		case p < 5:
+			c = 'a' + rune(r.Intn(26))
+		case p < 8:
+			c = ' ' + rune(r.Intn('~'-' '+1))
+		default:
+			ranges := [][2]rune{{0xA0, 0x17F}, {0x370, 0x3FF}, {0x3040, 0x309F}, {0x1F600, 0x1F64F}}
+			rg := ranges[r.Intn(len(ranges))]
+			c = rg[0] + rune(r.Intn(int(rg[1]-rg[0]+1)))
+		}
+		return runeSample(c)
+	}}
+}
+
+func runeSample(c rune) sample[rune] {
+	return sample[rune]{c, func() []sample[rune] {
+		if c == 'a' {
+			return nil
+		}
+		return []sample[rune]{runeSample('a')}
+	}}
+}
+
+// String creates a Gen of strings of at most size runes.
+//
+// The strings shrink by dropping runes and by replacing them with 'a'.
+func String() Gen[string] {
+	return Map(SliceOf(Rune()), func(rs []rune) string { return string(rs) })
+}
+
+// SliceOf creates a Gen of slices of at most size elements produced by elem.
+//
+// The slices shrink by dropping elements and by shrinking the remaining ones.
+func SliceOf[T any](elem Gen[T]) Gen[[]T] {
+	return Gen[[]T]{func(r *rand.Rand, size int) sample[[]T] {
+		elems := make([]sample[T], r.Intn(size+1))
+		for i := range elems {
+			elems[i] = elem.gen(r, size)
+		}
+		return listSample(elems, func(vs []T) []T { return vs })
+	}}
+}
+
+// MapOf creates a Gen of maps with at most size entries, with keys and values produced by the given generators.
+//
+// The maps shrink by dropping entries and by shrinking the values of the remaining ones.
+func MapOf[K comparable, V any](key Gen[K], value Gen[V]) Gen[map[K]V] {
+	type entry struct {
+		key   K
+		value V
+	}
+
+	entryGen := Gen[entry]{func(r *rand.Rand, size int) sample[entry] {
+		k := key.gen(r, size).value
+		return mapSample(value.gen(r, size), func(v V) entry { return entry{k, v} })
+	}}
+
+	return Gen[map[K]V]{func(r *rand.Rand, size int) sample[map[K]V] {
+		elems := make([]sample[entry], r.Intn(size+1))
+		for i := range elems {
+			elems[i] = entryGen.gen(r, size)
+		}
+		return listSample(elems, func(es []entry) map[K]V {
+			m := make(map[K]V, len(es))
+			for _, e := range es {
+				m[e.key] = e.value
+			}
+			return m
+		})
+	}}
+}
+
+// OneOf creates a Gen that produces values using one of gens, chosen at random.
+//
+// OneOf panics when no generators are given.
+func OneOf[T any](gens ...Gen[T]) Gen[T] {
+	if len(gens) == 0 {
+		panic("theprop.OneOf: no generators")
+	}
+	return Gen[T]{func(r *rand.Rand, size int) sample[T] {
+		return gens[r.Intn(len(gens))].gen(r, size)
+	}}
+}
+
+// Filter creates a Gen that produces only the values of g for which keep returns true.
+//
+// Values are shrunk only to other values that are kept.
+// Filter panics when it cannot produce a value after many attempts.
+func Filter[T any](g Gen[T], keep func(T) bool) Gen[T] {
+	return Gen[T]{func(r *rand.Rand, size int) sample[T] {
+		for i := 0; i < 1000; i++ {
+			if s := g.gen(r, size); keep(s.value) {
+				return filterSample(s, keep)
+			}
+		}
+		panic("theprop.Filter: too many values rejected")
+	}}
+}
+
+func filterSample[T any](s sample[T], keep func(T) bool) sample[T] {
+	return sample[T]{s.value, func() []sample[T] {
+		var out []sample[T]
+		for _, sh := range s.shrinks() {
+			if keep(sh.value) {
+				out = append(out, filterSample(sh, keep))
+			}
+		}
+		return out
+	}}
+}
+
+// Map creates a Gen that produces the values of g transformed by f.
+func Map[T, U any](g Gen[T], f func(T) U) Gen[U] {
+	return Gen[U]{func(r *rand.Rand, size int) sample[U] {
+		return mapSample(g.gen(r, size), f)
+	}}
+}
+
+func mapSample[T, U any](s sample[T], f func(T) U) sample[U] {
+	return sample[U]{f(s.value), func() []sample[U] {
+		shrinks := s.shrinks()
+		out := make([]sample[U], len(shrinks))
+		for i, sh := range shrinks {
+			out[i] = mapSample(sh, f)
+		}
+		return out
+	}}
+}
+
+// listSample builds a sample from a list of element samples.
+//
+// It shrinks by removing runs of elements, and then by shrinking single elements.
+func listSample[E, R any](elems []sample[E], build func([]E) R) sample[R] {
+	values := make([]E, len(elems))
+	for i, e := range elems {
+		values[i] = e.value
+	}
+
+	return sample[R]{build(values), func() []sample[R] {
+		var out []sample[R]
+
+		for n := len(elems); n > 0; n /= 2 {
+			for start := 0; start+n <= len(elems); start += n {
+				rest := make([]sample[E], 0, len(elems)-n)
+				rest = append(rest, elems[:start]...)
+				rest = append(rest, elems[start+n:]...)
+				out = append(out, listSample(rest, build))
+			}
+		}
+
+		for i, e := range elems {
+			for _, sh := range e.shrinks() {
+				changed := make([]sample[E], len(elems))
+				copy(changed, elems)
+				changed[i] = sh
+				out = append(out, listSample(changed, build))
+			}
+		}
+
+		return out
+	}}
+}
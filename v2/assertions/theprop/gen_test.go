@@ -0,0 +1,154 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theprop_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/theprop"
+)
+
+func TestIntRange(t *testing.T) {
+	// given
+	r := rand.New(rand.NewSource(1))
+	gen := theprop.IntRange(-3, 5)
+
+	// when
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		seen[gen.Generate(r, 1)] = true
+	}
+
+	// then
+	assert.Using(t.Errorf).That(theval.Equal(len(seen), 9))
+	for n := range seen {
+		assert.Using(t.Errorf).
+			That(theval.LessThan(-4, n)).
+			That(theval.LessThan(n, 6))
+	}
+}
+
+func TestIntRangeShrinksTowardsLowerBound(t *testing.T) {
+	// given
+	var errFunc assertiontesting.ErrFunc
+
+	small := func(n int) (bool, string) { return theval.LessThan(n, 15) }
+
+	// when
+	assert.Using(errFunc.Record).That(theprop.Check(theprop.Config{Seed: 3}, theprop.IntRange(10, 20), small))
+
+	// then
+	assert.Using(t.Errorf).
+		That(errFunc.Called()).
+		That(errFunc.MessageFormatsTo(
+			"property failed after 3 runs (seed 3, rerun with THEPROP_SEED=3): got 15 >= 15\n" +
+				"minimal counterexample (after 2 shrinks): 15\n" +
+				"original counterexample: 18"))
+}
+
+func TestFilter(t *testing.T) {
+	// given
+	var errFunc assertiontesting.ErrFunc
+
+	even := theprop.Filter(theprop.Int(), func(n int) bool { return n%2 == 0 })
+	small := func(n int) (bool, string) {
+		return n%2 == 0 && n < 5, fmt.Sprintf("got %d", n)
+	}
+
+	// when
+	assert.Using(errFunc.Record).That(theprop.Check(theprop.Config{Seed: 3}, even, small))
+
+	// then
+	assert.Using(t.Errorf).
+		That(errFunc.Called()).
+		That(errFunc.MessageFormatsTo(
+			"property failed after 12 runs (seed 3, rerun with THEPROP_SEED=3): got 6\n" +
+				"minimal counterexample (after 1 shrinks): 6\n" +
+				"original counterexample: 12"))
+}
+
+func TestStruct(t *testing.T) {
+
+	type node struct {
+		Label    string
+		Weight   uint8
+		Ratio    float64
+		Children []node
+		Next     *node
+		hidden   int
+	}
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		hiddenZero := func(n node) (bool, string) { return theval.Equal(n.hidden, 0) }
+
+		// when
+		assert.Using(errFunc.Record).That(theprop.Check(theprop.Config{Seed: 5}, theprop.Struct[node](), hiddenZero))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		type pair struct {
+			A, B int
+		}
+
+		var errFunc assertiontesting.ErrFunc
+
+		ordered := func(p pair) (bool, string) { return theval.LessThan(p.A, p.B) }
+
+		// when
+		assert.Using(errFunc.Record).That(theprop.Check(theprop.Config{Seed: 5}, theprop.Struct[pair](), ordered))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				"property failed after 1 runs (seed 5, rerun with THEPROP_SEED=5): got 0 >= 0\n" +
+					"minimal counterexample (after 1 shrinks): theprop_test.pair{A:0, B:0}\n" +
+					"original counterexample: theprop_test.pair{A:1, B:0}"))
+	})
+
+}
+
+func TestStructPanicsOnNonStructType(t *testing.T) {
+	// given
+	defer func() {
+		// then
+		msg, _ := recover().(string)
+		assert.Using(t.Errorf).That(theval.Equal(msg, "theprop.Struct: int is not a struct type"))
+	}()
+
+	// when
+	theprop.Struct[int]()
+}
@@ -0,0 +1,248 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theprop
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// Struct creates a Gen of structs of type T, with random values in all the exported fields.
+//
+// The fields are generated based on their types using reflection.
+// Numbers, booleans, strings, slices, maps, arrays, pointers and nested structs are supported.
+// Fields of other types and unexported fields are left with their zero values.
+// The structs shrink by shrinking one field at a time.
+//
+// Struct panics when T is not a struct type.
+func Struct[T any]() Gen[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("theprop.Struct: %s is not a struct type", t))
+	}
+	return Map(reflectGen(t, map[reflect.Type]bool{}), func(v reflect.Value) T { return v.Interface().(T) })
+}
+
+// reflectGen creates a Gen of values of type t.
+//
+// The structs being visited are tracked to break cycles in recursive types.
+func reflectGen(t reflect.Type, visiting map[reflect.Type]bool) Gen[reflect.Value] {
+	switch t.Kind() {
+
+	case reflect.Bool:
+		return convert(Bool(), t)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		limit := maxInt(t)
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			n := size
+			if int64(n) > limit {
+				n = int(limit)
+			}
+			return IntRange(-n, n).gen(r, size).convert(t)
+		}}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		limit := maxInt(t)
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			n := size
+			if int64(n) > limit {
+				n = int(limit)
+			}
+			return mapSample(IntRange(0, n).gen(r, size), func(i int) reflect.Value {
+				return reflect.ValueOf(uint64(i)).Convert(t)
+			})
+		}}
+
+	case reflect.Float32, reflect.Float64:
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			return mapSample(floatSample(r.NormFloat64()*float64(size)), func(f float64) reflect.Value {
+				return reflect.ValueOf(f).Convert(t)
+			})
+		}}
+
+	case reflect.String:
+		return convert(String(), t)
+
+	case reflect.Slice:
+		elem := reflectGen(t.Elem(), visiting)
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			elems := make([]sample[reflect.Value], r.Intn(size+1))
+			for i := range elems {
+				elems[i] = elem.gen(r, size)
+			}
+			return listSample(elems, func(vs []reflect.Value) reflect.Value {
+				s := reflect.MakeSlice(t, len(vs), len(vs))
+				for i, v := range vs {
+					s.Index(i).Set(v)
+				}
+				return s
+			})
+		}}
+
+	case reflect.Array:
+		elem := reflectGen(t.Elem(), visiting)
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			elems := make([]sample[reflect.Value], t.Len())
+			for i := range elems {
+				elems[i] = elem.gen(r, size)
+			}
+			return fieldsSample(elems, func(vs []reflect.Value) reflect.Value {
+				a := reflect.New(t).Elem()
+				for i, v := range vs {
+					a.Index(i).Set(v)
+				}
+				return a
+			})
+		}}
+
+	case reflect.Map:
+		key, value := reflectGen(t.Key(), visiting), reflectGen(t.Elem(), visiting)
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			entries := make([]sample[[2]reflect.Value], r.Intn(size+1))
+			for i := range entries {
+				k := key.gen(r, size).value
+				entries[i] = mapSample(value.gen(r, size), func(v reflect.Value) [2]reflect.Value {
+					return [2]reflect.Value{k, v}
+				})
+			}
+			return listSample(entries, func(es [][2]reflect.Value) reflect.Value {
+				m := reflect.MakeMapWithSize(t, len(es))
+				for _, e := range es {
+					m.SetMapIndex(e[0], e[1])
+				}
+				return m
+			})
+		}}
+
+	case reflect.Pointer:
+		elem := reflectGen(t.Elem(), visiting)
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			if r.Intn(10) == 0 {
+				return sample[reflect.Value]{reflect.Zero(t), noShrinks[reflect.Value]}
+			}
+			return pointerSample(elem.gen(r, size), t)
+		}}
+
+	case reflect.Struct:
+		if visiting[t] {
+			return Just(reflect.Zero(t))
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		var fields []int
+		var gens []Gen[reflect.Value]
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				fields = append(fields, i)
+				gens = append(gens, reflectGen(t.Field(i).Type, visiting))
+			}
+		}
+		return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+			values := make([]sample[reflect.Value], len(gens))
+			for i, g := range gens {
+				values[i] = g.gen(r, size)
+			}
+			return fieldsSample(values, func(vs []reflect.Value) reflect.Value {
+				s := reflect.New(t).Elem()
+				for i, v := range vs {
+					s.Field(fields[i]).Set(v)
+				}
+				return s
+			})
+		}}
+
+	default:
+		return Just(reflect.Zero(t))
+	}
+}
+
+// maxInt returns the largest value of a signed integer type with as many bits as t.
+func maxInt(t reflect.Type) int64 {
+	return math.MaxInt64 >> (64 - t.Bits())
+}
+
+func convert[T any](g Gen[T], t reflect.Type) Gen[reflect.Value] {
+	return Gen[reflect.Value]{func(r *rand.Rand, size int) sample[reflect.Value] {
+		return g.gen(r, size).convert(t)
+	}}
+}
+
+func (s sample[T]) convert(t reflect.Type) sample[reflect.Value] {
+	return mapSample(s, func(v T) reflect.Value { return reflect.ValueOf(v).Convert(t) })
+}
+
+func floatSample(f float64) sample[float64] {
+	return sample[float64]{f, func() []sample[float64] {
+		if f == 0 {
+			return nil
+		}
+		out := []sample[float64]{floatSample(0)}
+		if t := math.Trunc(f); t != f {
+			out = append(out, floatSample(t))
+		}
+		if h := f / 2; math.Abs(h) >= 1 {
+			out = append(out, floatSample(h))
+		}
+		return out
+	}}
+}
+
+func pointerSample(elem sample[reflect.Value], t reflect.Type) sample[reflect.Value] {
+	p := reflect.New(t.Elem())
+	p.Elem().Set(elem.value)
+
+	return sample[reflect.Value]{p, func() []sample[reflect.Value] {
+		out := []sample[reflect.Value]{{reflect.Zero(t), noShrinks[reflect.Value]}}
+		for _, sh := range elem.shrinks() {
+			out = append(out, pointerSample(sh, t))
+		}
+		return out
+	}}
+}
+
+// fieldsSample builds a sample from a fixed number of part samples.
+//
+// It shrinks by shrinking one part at a time.
+func fieldsSample[E, R any](parts []sample[E], build func([]E) R) sample[R] {
+	values := make([]E, len(parts))
+	for i, p := range parts {
+		values[i] = p.value
+	}
+
+	return sample[R]{build(values), func() []sample[R] {
+		var out []sample[R]
+		for i, p := range parts {
+			for _, sh := range p.shrinks() {
+				changed := make([]sample[E], len(parts))
+				copy(changed, parts)
+				changed[i] = sh
+				out = append(out, fieldsSample(changed, build))
+			}
+		}
+		return out
+	}}
+}
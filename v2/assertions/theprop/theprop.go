@@ -0,0 +1,189 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package theprop checks properties of randomly generated values using reusable assertions.
+//
+// A property is any function of a single value that returns (bool, string), just like a reusable assertion.
+// ForAll checks it against many values produced by a generator:
+//
+//	theprop.ForAll(t, theprop.SliceOf(theprop.Int()), func(s []int) (bool, string) {
+//	    return theslice.Equal(reverse(reverse(s)), s)
+//	})
+//
+// When the property fails, the failing value is shrunk to a minimal counterexample before it is reported.
+//
+// # Replaying failures
+//
+// The values are generated from a random seed, which is included in failure messages.
+// To generate the same values again, set the THEPROP_SEED environment variable to that seed,
+// or set Seed in a Config.
+package theprop
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/internal/property"
+)
+
+// SeedEnv is the name of the environment variable that sets the default seed.
+const SeedEnv = "THEPROP_SEED"
+
+// A Config controls how properties are checked.
+//
+// The zero value of each field means its default.
+type Config struct {
+	// Runs is the number of values to check the property against.
+	// It defaults to 100.
+	Runs int
+
+	// MaxSize is the size of the last generated values.
+	// The size grows from 1 up to MaxSize over the runs.
+	// It defaults to 100.
+	MaxSize int
+
+	// MaxShrinks is the maximum number of values tried while shrinking a counterexample.
+	// It defaults to 1000.
+	MaxShrinks int
+
+	// Seed is the seed of the random values.
+	// It defaults to the value of the THEPROP_SEED environment variable when that is set,
+	// and to a seed based on the current time otherwise.
+	Seed int64
+}
+
+// ForAll asserts that prop holds for values produced by gen.
+//
+// Failures are reported using t.Errorf.
+// The property is checked using the default Config.
+func ForAll[T any](t testing.TB, gen Gen[T], prop func(T) (bool, string)) {
+	t.Helper()
+	ok, msg := Check(Config{}, gen, prop)
+	assert.Using(t.Errorf).That(ok, "%s", msg)
+}
+
+// Check asserts that prop holds for values produced by gen.
+//
+// A property that panics fails.
+// On failure, the message describes the minimal counterexample found, the seed, and the original counterexample.
+func Check[T any](cfg Config, gen Gen[T], prop func(T) (bool, string)) (bool, string) {
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return false, err.Error()
+	}
+
+	r := rand.New(rand.NewSource(cfg.Seed))
+	for run := 0; run < cfg.Runs; run++ {
+		size := cfg.size(run)
+		s := gen.gen(r, size)
+
+		if ok, _, _ := holds(prop, s.value); ok {
+			continue
+		}
+
+		minimal, shrinks := shrink(s, prop, cfg.MaxShrinks)
+		_, msg, stack := holds(prop, minimal.value)
+
+		msg = fmt.Sprintf(
+			"property failed after %d runs (seed %d, rerun with %s=%d): %s\n"+
+				"minimal counterexample (after %d shrinks): %#v\n"+
+				"original counterexample: %#v",
+			run+1, cfg.Seed, SeedEnv, cfg.Seed, msg,
+			shrinks, minimal.value,
+			s.value)
+		if stack != nil {
+			msg += "\n\n" + string(stack)
+		}
+		return false, msg
+	}
+	return true, ""
+}
+
+// size returns the size of the values generated in the given run, growing from 1 in the first run to MaxSize in the last.
+func (cfg Config) size(run int) int {
+	if cfg.Runs == 1 {
+		return cfg.MaxSize
+	}
+	return 1 + run*(cfg.MaxSize-1)/(cfg.Runs-1)
+}
+
+func (cfg Config) withDefaults() (Config, error) {
+	if cfg.Runs <= 0 {
+		cfg.Runs = 100
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 100
+	}
+	if cfg.MaxShrinks <= 0 {
+		cfg.MaxShrinks = 1000
+	}
+
+	if cfg.Seed != 0 {
+		return cfg, nil
+	}
+
+	if env := os.Getenv(SeedEnv); env != "" {
+		seed, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid %s %q: %s", SeedEnv, env, err)
+		}
+		cfg.Seed = seed
+		return cfg, nil
+	}
+
+	cfg.Seed = time.Now().UnixNano()
+	return cfg, nil
+}
+
+// shrink looks for the simplest value derived from s for which prop still fails.
+//
+// It returns the value found and the number of successful shrinking steps.
+func shrink[T any](s sample[T], prop func(T) (bool, string), maxTries int) (sample[T], int) {
+	steps, tries := 0, 0
+	for tries < maxTries {
+		shrunk := false
+		for _, candidate := range s.shrinks() {
+			tries++
+			if ok, _, _ := holds(prop, candidate.value); !ok {
+				s, shrunk = candidate, true
+				steps++
+				break
+			}
+			if tries >= maxTries {
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return s, steps
+}
+
+func holds[T any](prop func(T) (bool, string), v T) (bool, string, []byte) {
+	return property.Holds(func() (bool, string) { return prop(v) })
+}
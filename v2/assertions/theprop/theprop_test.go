@@ -0,0 +1,207 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theprop_test
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/theprop"
+)
+
+func TestCheck(t *testing.T) {
+
+	cfg := theprop.Config{Seed: 7}
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		reversedTwice := func(s []int) (bool, string) {
+			return theslice.Equal(reverse(reverse(s)), s)
+		}
+
+		// when
+		assert.Using(errFunc.Record).That(theprop.Check(cfg, theprop.SliceOf(theprop.Int()), reversedTwice))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False/Int", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		lessThan10 := func(n int) (bool, string) { return theval.LessThan(n, 10) }
+
+		// when
+		assert.Using(errFunc.Record).That(theprop.Check(cfg, theprop.Int(), lessThan10))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				"property failed after 17 runs (seed 7, rerun with THEPROP_SEED=7): got 10 >= 10\n" +
+					"minimal counterexample (after 3 shrinks): 10\n" +
+					"original counterexample: 15"))
+	})
+
+	t.Run("False/Slice", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		reversed := func(s []int) (bool, string) { return theslice.Equal(reverse(s), s) }
+
+		// when
+		assert.Using(errFunc.Record).That(theprop.Check(cfg, theprop.SliceOf(theprop.Int()), reversed))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				"property failed after 4 runs (seed 7, rerun with THEPROP_SEED=7): got slice []int{-1, 0}, not []int{0, -1}: element at position 0 is -1, not 0; element at position 1 is 0, not -1\n" +
+					"minimal counterexample (after 3 shrinks): []int{0, -1}\n" +
+					"original counterexample: []int{1, -3}"))
+	})
+
+	t.Run("False/Panic", func(t *testing.T) {
+		// given
+		var rec assertiontesting.Recorder
+
+		first := func(s []int) (bool, string) { return theval.Equal(s[0], s[0]) }
+
+		// when
+		assert.Using(rec.Record).That(theprop.Check(cfg, theprop.SliceOf(theprop.Int()), first))
+
+		// then
+		msg := "property failed after 1 runs (seed 7, rerun with THEPROP_SEED=7): panic: runtime error: index out of range [0] with length 0\n" +
+			"minimal counterexample (after 0 shrinks): []int{}\n" +
+			"original counterexample: []int{}"
+		assert.Using(t.Errorf).
+			That(rec.CallCount(1)).
+			That(rec.MessageMatches(0, regexp.MustCompile(`^`+regexp.QuoteMeta(msg)+`\n\ngoroutine \d+ \[running\]:\n(?s:.*)theprop_test\.TestCheck`)))
+	})
+
+	t.Run("False/InvalidSeedEnv", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		t.Setenv(theprop.SeedEnv, "x")
+
+		holds := func(int) (bool, string) { return true, "" }
+
+		// when
+		assert.Using(errFunc.Record).That(theprop.Check(theprop.Config{}, theprop.Int(), holds))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`invalid THEPROP_SEED "x": strconv.ParseInt: parsing "x": invalid syntax`))
+	})
+
+}
+
+func TestCheckGrowsSizeUpToMaxSize(t *testing.T) {
+	for _, tt := range []struct {
+		runs, maxSize int
+		want          []int
+	}{
+		{5, 9, []int{1, 3, 5, 7, 9}},
+		{3, 100, []int{1, 50, 100}},
+		{1, 9, []int{9}},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Runs%d/MaxSize%d", tt.runs, tt.maxSize), func(t *testing.T) {
+			// given
+			var sizes []int
+			gen := theprop.FromFunc(func(_ *rand.Rand, size int) int {
+				sizes = append(sizes, size)
+				return size
+			})
+			holds := func(int) (bool, string) { return true, "" }
+
+			// when
+			theprop.Check(theprop.Config{Seed: 1, Runs: tt.runs, MaxSize: tt.maxSize}, gen, holds)
+
+			// then
+			assert.Using(t.Errorf).That(theslice.Equal(sizes, tt.want))
+		})
+	}
+}
+
+func TestCheckReplaysSeed(t *testing.T) {
+	// given
+	t.Setenv(theprop.SeedEnv, "42")
+
+	var first, second assertiontesting.ErrFunc
+
+	positive := func(n int) (bool, string) { return theval.LessThan(0, n) }
+
+	// when
+	assert.Using(first.Record).That(theprop.Check(theprop.Config{}, theprop.Int(), positive))
+	assert.Using(second.Record).That(theprop.Check(theprop.Config{Seed: 42}, theprop.Int(), positive))
+
+	// then
+	assert.Using(t.Errorf).
+		That(first.Called()).
+		That(first.MessageFormatsTo(
+			"property failed after 2 runs (seed 42, rerun with THEPROP_SEED=42): got 0 >= 0\n" +
+				"minimal counterexample (after 0 shrinks): 0\n" +
+				"original counterexample: 0")).
+		That(second.Called()).
+		That(second.MessageFormatsTo(
+			"property failed after 2 runs (seed 42, rerun with THEPROP_SEED=42): got 0 >= 0\n" +
+				"minimal counterexample (after 0 shrinks): 0\n" +
+				"original counterexample: 0"))
+}
+
+func reverse(s []int) []int {
+	out := make([]int, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+	return out
+}
+
+func ExampleCheck() {
+	cfg := theprop.Config{Seed: 1}
+
+	shortNames := func(u struct{ Name string }) (bool, string) {
+		n := len([]rune(u.Name))
+		return n < 3, fmt.Sprintf("name %q has %d runes", u.Name, n)
+	}
+
+	_, msg := theprop.Check(cfg, theprop.Struct[struct{ Name string }](), shortNames)
+	fmt.Println(msg)
+
+	// Output:
+	// property failed after 7 runs (seed 1, rerun with THEPROP_SEED=1): name "aaa" has 3 runes
+	// minimal counterexample (after 4 shrinks): struct { Name string }{Name:"aaa"}
+	// original counterexample: struct { Name string }{Name:"]5み|\u3097"}
+}
@@ -0,0 +1,73 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theslice_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/theslice"
+)
+
+func TestConformance(t *testing.T) {
+
+	t.Run("Empty", func(t *testing.T) {
+		assertiontesting.Conformance[[]int]{
+			Assertion: theslice.Empty[[]int],
+			Not:       theslice.NotEmpty[[]int],
+			Pass:      [][]int{nil, {}},
+			Fail:      [][]int{{1}, {1, 2}},
+		}.Run(t)
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		p := assertiontesting.PairOf[[]int, []int]
+		assertiontesting.Conformance[assertiontesting.Pair[[]int, []int]]{
+			Assertion: assertiontesting.OnPair(theslice.Equal[[]int]),
+			Not:       assertiontesting.OnPair(theslice.NotEqual[[]int]),
+			Pass: []assertiontesting.Pair[[]int, []int]{
+				p(nil, nil),
+				p([]int{}, []int{}),
+				p([]int{1, 2}, []int{1, 2}),
+			},
+			Fail: []assertiontesting.Pair[[]int, []int]{
+				p(nil, []int{}),
+				p([]int{}, nil),
+				p([]int{1}, []int{1, 2}),
+				p([]int{1, 3}, []int{1, 2}),
+			},
+		}.Run(t)
+	})
+
+	t.Run("Length", func(t *testing.T) {
+		p := assertiontesting.PairOf[[]int, int]
+		assertiontesting.Conformance[assertiontesting.Pair[[]int, int]]{
+			Assertion: assertiontesting.OnPair(theslice.Length[[]int]),
+			Not:       assertiontesting.OnPair(theslice.LengthNot[[]int]),
+			Pass:      []assertiontesting.Pair[[]int, int]{p(nil, 0), p([]int{1, 2}, 2)},
+			Fail:      []assertiontesting.Pair[[]int, int]{p(nil, 1), p([]int{1, 2}, 3)},
+		}.Run(t)
+	})
+
+}
@@ -0,0 +1,184 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thesnapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+const source = `package example_test
+
+func TestExample(t *testing.T) {
+	thesnapshot.Match(t, first(), "")
+	thesnapshot.Match(t, second(),
+		"old")
+	Match(t, third(), f(""))
+}
+`
+
+func TestRewrite(t *testing.T) {
+
+	t.Run("Single", func(t *testing.T) {
+		// given
+		files, file := sourceFile(t)
+
+		// when
+		err := files.rewrite(file, 4, "new")
+
+		// then
+		assert.Using(t.Errorf).
+			That(theerr.IsNil(err)).
+			That(theval.Equal(readFile(t, file), `package example_test
+
+func TestExample(t *testing.T) {
+	thesnapshot.Match(t, first(), "new")
+	thesnapshot.Match(t, second(),
+		"old")
+	Match(t, third(), f(""))
+}
+`))
+	})
+
+	t.Run("LinesShifted", func(t *testing.T) {
+		// given
+		files, file := sourceFile(t)
+
+		// when
+		errFirst := files.rewrite(file, 4, "two\nlines\n")
+		errSecond := files.rewrite(file, 6, `"quoted"`)
+
+		// then
+		assert.Using(t.Errorf).
+			That(theerr.IsNil(errFirst)).
+			That(theerr.IsNil(errSecond)).
+			That(theval.Equal(readFile(t, file), "package example_test\n"+
+				"\n"+
+				"func TestExample(t *testing.T) {\n"+
+				"\tthesnapshot.Match(t, first(), `two\nlines\n`)\n"+
+				"\tthesnapshot.Match(t, second(),\n"+
+				"\t\t\"\\\"quoted\\\"\")\n"+
+				"\tMatch(t, third(), f(\"\"))\n"+
+				"}\n"))
+	})
+
+	t.Run("SameValueTwice", func(t *testing.T) {
+		// given
+		files, file := sourceFile(t)
+
+		// when
+		errFirst := files.rewrite(file, 4, "new")
+		errSecond := files.rewrite(file, 4, "new")
+
+		// then
+		assert.Using(t.Errorf).
+			That(theerr.IsNil(errFirst)).
+			That(theerr.IsNil(errSecond)).
+			That(theval.Equal(readFile(t, file), `package example_test
+
+func TestExample(t *testing.T) {
+	thesnapshot.Match(t, first(), "new")
+	thesnapshot.Match(t, second(),
+		"old")
+	Match(t, third(), f(""))
+}
+`))
+	})
+
+	t.Run("DifferentValueTwice", func(t *testing.T) {
+		// given
+		files, file := sourceFile(t)
+		errFirst := files.rewrite(file, 4, "first")
+
+		// when
+		errSecond := files.rewrite(file, 4, "second")
+
+		// then
+		assert.Using(t.Errorf).
+			That(theerr.IsNil(errFirst)).
+			That(errSecond != nil, "got no error").
+			That(theval.Equal(readFile(t, file), `package example_test
+
+func TestExample(t *testing.T) {
+	thesnapshot.Match(t, first(), "first")
+	thesnapshot.Match(t, second(),
+		"old")
+	Match(t, third(), f(""))
+}
+`))
+	})
+
+	t.Run("NotALiteral", func(t *testing.T) {
+		// given
+		files, file := sourceFile(t)
+
+		// when
+		err := files.rewrite(file, 7, "new")
+
+		// then
+		assert.Using(t.Errorf).
+			That(err != nil, "got no error").
+			That(theval.Equal(readFile(t, file), source))
+	})
+
+	t.Run("NoCall", func(t *testing.T) {
+		// given
+		files, file := sourceFile(t)
+
+		// when
+		err := files.rewrite(file, 1, "new")
+
+		// then
+		assert.Using(t.Errorf).
+			That(err != nil, "got no error").
+			That(theval.Equal(readFile(t, file), source))
+	})
+
+}
+
+func sourceFile(t *testing.T) (*sourceFiles, string) {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "example_test.go")
+	err := os.WriteFile(file, []byte(source), 0o644)
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+
+	files := &sourceFiles{
+		originals: map[string][]byte{},
+		edits:     map[string]map[int]edit{},
+	}
+	return files, file
+}
+
+func readFile(t *testing.T, file string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(file)
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	return string(content)
+}
@@ -0,0 +1,234 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thesnapshot provides inline snapshot assertions.
+//
+// An inline snapshot is the expected value of a test written as a string literal right in the test:
+//
+//	thesnapshot.Match(t, render(order), `Order #7: 2 items`)
+//
+// When the snapshot is an empty literal, Match fills it in with the actual value.
+// When tests are run with the -update flag, or with the THESNAPSHOT_UPDATE environment variable set to a non-empty value,
+// Match overwrites all the snapshots that do not match the actual values.
+// In both cases the literal in the calling source file is rewritten, and the test passes.
+// The change takes effect the next time the tests are compiled.
+//
+// To find the literal to rewrite, Match looks for a call with three arguments named Match
+// at the position it was called from.
+// The snapshot must be passed to it directly as a string literal,
+// and Match must not be called through a helper function.
+// A snapshot checked more than once, like in a loop or a table-driven test,
+// can only be updated when it gets the same value each time.
+// Source files are located using the paths recorded in the test binary,
+// so rewriting does not work in tests built with -trimpath.
+//
+// The -update flag is shared with the thegolden package, and defined the same way.
+// See [thegolden] for when it can be passed to go test.
+//
+// [thegolden]: https://pkg.go.dev/github.com/szabba/assert/v2/assertions/thegolden
+package thesnapshot
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/internal/diff"
+	"github.com/szabba/assert/v2/internal/update"
+)
+
+// UpdateEnv is the name of the environment variable that enables updating snapshots.
+const UpdateEnv = "THESNAPSHOT_UPDATE"
+
+// Match asserts that got is equal to the snapshot want.
+//
+// Failures are reported using t.Errorf.
+// For how snapshots are filled in and updated see the package documentation.
+func Match(t testing.TB, got, want string) {
+	t.Helper()
+
+	if got == want {
+		return
+	}
+
+	if want != "" && !update.Enabled(UpdateEnv) {
+		assert.Using(t.Errorf).That(
+			false,
+			"got value different from snapshot, run the tests with -update to update it:\n%s",
+			diff.Unified("snapshot", "got", want, got))
+		return
+	}
+
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		t.Errorf("cannot update snapshot: caller of thesnapshot.Match is unknown")
+		return
+	}
+
+	if err := files.rewrite(file, line, got); err != nil {
+		t.Errorf("cannot update snapshot at %s:%d: %s", file, line, err)
+		return
+	}
+	t.Logf("updated snapshot at %s:%d", file, line)
+}
+
+// files is the set of source files with snapshots that have been rewritten.
+var files = sourceFiles{
+	originals: map[string][]byte{},
+	edits:     map[string]map[int]edit{},
+}
+
+type sourceFiles struct {
+	mu sync.Mutex
+
+	// The contents of each file from before any snapshot in it was rewritten.
+	// Line numbers reported by the runtime refer to them.
+	originals map[string][]byte
+
+	// The rewrites of each file, keyed by the offset of the replaced literal.
+	edits map[string]map[int]edit
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+func (fs *sourceFiles) rewrite(file string, line int, got string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	src, ok := fs.originals[file]
+	if !ok {
+		var err error
+		src, err = os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		fs.originals[file] = src
+		fs.edits[file] = map[int]edit{}
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, 0)
+	if err != nil {
+		return err
+	}
+
+	lit, err := findSnapshot(fset, f, line)
+	if err != nil {
+		return err
+	}
+
+	start, end := fset.Position(lit.Pos()).Offset, fset.Position(lit.End()).Offset
+	text := literal(got)
+	if prev, ok := fs.edits[file][start]; ok && prev.text != text {
+		return fmt.Errorf("the snapshot was already updated to %s, not %s; a snapshot checked more than once must get the same value each time", prev.text, text)
+	}
+	fs.edits[file][start] = edit{start, end, text}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, apply(src, fs.edits[file]), info.Mode())
+}
+
+// findSnapshot finds the snapshot literal of the Match call at line.
+func findSnapshot(fset *token.FileSet, f *ast.File, line int) (*ast.BasicLit, error) {
+	var found *ast.CallExpr
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 3 || !isMatch(call.Fun) {
+			return true
+		}
+
+		from, to := fset.Position(call.Pos()).Line, fset.Position(call.End()).Line
+		if line < from || to < line {
+			return true
+		}
+
+		if found == nil || call.End()-call.Pos() < found.End()-found.Pos() {
+			found = call
+		}
+		return true
+	})
+
+	if found == nil {
+		return nil, fmt.Errorf("no call to Match at line %d", line)
+	}
+
+	lit, ok := found.Args[2].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, errors.New("the snapshot is not a string literal")
+	}
+	return lit, nil
+}
+
+func isMatch(fun ast.Expr) bool {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun.Name == "Match"
+	case *ast.SelectorExpr:
+		return fun.Sel.Name == "Match"
+	default:
+		return false
+	}
+}
+
+// literal returns a Go string literal for s.
+//
+// Multi-line strings become raw string literals whenever possible.
+func literal(s string) string {
+	if strings.Contains(s, "\n") && !strings.ContainsAny(s, "`\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func apply(src []byte, edits map[int]edit) []byte {
+	sorted := make([]edit, 0, len(edits))
+	for _, e := range edits {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+
+	out := make([]byte, 0, len(src))
+	last := 0
+	for _, e := range sorted {
+		out = append(out, src[last:e.start]...)
+		out = append(out, e.text...)
+		last = e.end
+	}
+	return append(out, src[last:]...)
+}
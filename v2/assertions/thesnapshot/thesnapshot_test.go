@@ -0,0 +1,80 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thesnapshot_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thesnapshot"
+)
+
+func TestMatch(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		tb := &recordingTB{TB: t}
+
+		// when
+		thesnapshot.Match(tb, "a\nb\n", `a
+b
+`)
+
+		// then
+		assert.Using(t.Errorf).That(tb.errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		if f := flag.Lookup("update"); f != nil && f.Value.String() == "true" {
+			// The failing Match would rewrite the snapshot in this file.
+			t.Skip("snapshots are being updated")
+		}
+		t.Setenv(thesnapshot.UpdateEnv, "")
+		tb := &recordingTB{TB: t}
+
+		// when
+		thesnapshot.Match(tb, "a\nc\n", "a\nb\n")
+
+		// then
+		assert.Using(t.Errorf).
+			That(tb.errFunc.Called()).
+			That(tb.errFunc.MessageFormatsTo(
+				"got value different from snapshot, run the tests with -update to update it:\n" +
+					"--- snapshot\n+++ got\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n"))
+	})
+
+}
+
+// recordingTB records the failures reported to it instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errFunc assertiontesting.ErrFunc
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Errorf(msgFmt string, args ...any) { tb.errFunc.Record(msgFmt, args...) }
@@ -0,0 +1,242 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thetestjson provides reusable assertions about the output of go test -json.
+//
+// Parse reads the stream of events printed by go test -json (see [test2json]) into a Run:
+//
+//	run, err := thetestjson.Parse(bytes.NewReader(out))
+//	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+//
+// The assertions take the run and the name of a test:
+//
+//	assert.Using(t.Errorf).
+//	    That(thetestjson.Passed(run, "TestParse")).
+//	    That(thetestjson.Failed(run, "TestParse/Empty")).
+//	    That(thetestjson.OutputContains(run, "TestParse/Empty", "unexpected EOF"))
+//
+// The same assertions are available as methods of Run:
+//
+//	assert.Using(t.Errorf).That(run.ElapsedLessThan("TestParse", time.Second))
+//
+// Subtests are named like in the events, for example "TestParse/Empty".
+// The empty name stands for the package itself, whose result is reported without a test name.
+// When the run covers many packages, the events of all of them are taken into account.
+//
+// Failure messages include the output of the test, truncated to MaxOutputLength bytes.
+//
+// [test2json]: https://pkg.go.dev/cmd/test2json
+package thetestjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MaxOutputLength is the maximum number of bytes of test output included in a failure message.
+var MaxOutputLength = 1024
+
+// An Event is a single line printed by go test -json.
+type Event struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64 // In seconds.
+	Output  string
+}
+
+// A Run holds the events of a go test -json run.
+type Run struct {
+	Events []Event
+}
+
+// Parse reads the events printed by go test -json from r.
+func Parse(r io.Reader) (*Run, error) {
+	run := &Run{}
+	dec := json.NewDecoder(r)
+	for {
+		var e Event
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			return run, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("thetestjson: event %d: %w", len(run.Events)+1, err)
+		}
+		run.Events = append(run.Events, e)
+	}
+}
+
+// Tests returns the names of the tests that have events in the run, in the order they first appear.
+func (run *Run) Tests() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, e := range run.Events {
+		if e.Test != "" && !seen[e.Test] {
+			seen[e.Test] = true
+			names = append(names, e.Test)
+		}
+	}
+	return names
+}
+
+// Passed asserts that the test passed.
+func (run *Run) Passed(test string) (bool, string) { return Passed(run, test) }
+
+// Failed asserts that the test failed.
+func (run *Run) Failed(test string) (bool, string) { return Failed(run, test) }
+
+// Skipped asserts that the test was skipped.
+func (run *Run) Skipped(test string) (bool, string) { return Skipped(run, test) }
+
+// OutputContains asserts that the output of the test contains substr.
+func (run *Run) OutputContains(test, substr string) (bool, string) {
+	return OutputContains(run, test, substr)
+}
+
+// ElapsedLessThan asserts that the test took less than max.
+func (run *Run) ElapsedLessThan(test string, max time.Duration) (bool, string) {
+	return ElapsedLessThan(run, test, max)
+}
+
+// Passed asserts that the test passed.
+//
+// When the run covers many packages with a test of the same name, it must have passed in all of them.
+func Passed(run *Run, test string) (bool, string) {
+	return hasResult(run, test, "pass")
+}
+
+// Failed asserts that the test failed.
+//
+// When the run covers many packages with a test of the same name, it must have failed in one of them.
+func Failed(run *Run, test string) (bool, string) {
+	results := run.results(test)
+	if len(results) == 0 {
+		return false, noResult(run, test)
+	}
+	for _, e := range results {
+		if e.Action == "fail" {
+			return true, ""
+		}
+	}
+	return false, failure(run, test, "%s %s, not failed", describe(test), finished[results[0].Action])
+}
+
+// Skipped asserts that the test was skipped.
+func Skipped(run *Run, test string) (bool, string) {
+	return hasResult(run, test, "skip")
+}
+
+// OutputContains asserts that the output of the test contains substr.
+func OutputContains(run *Run, test, substr string) (bool, string) {
+	out := run.output(test)
+	if strings.Contains(out, substr) {
+		return true, ""
+	}
+	return false, failure(run, test, "output of %s does not contain %q", describe(test), substr)
+}
+
+// ElapsedLessThan asserts that the test took less than max.
+//
+// When the run covers many packages with a test of the same name, all of them must have taken less than max.
+func ElapsedLessThan(run *Run, test string, max time.Duration) (bool, string) {
+	results := run.results(test)
+	if len(results) == 0 {
+		return false, noResult(run, test)
+	}
+	for _, e := range results {
+		if elapsed := time.Duration(e.Elapsed * float64(time.Second)); elapsed >= max {
+			return false, fmt.Sprintf("%s took %s, not less than %s", describe(test), elapsed, max)
+		}
+	}
+	return true, ""
+}
+
+func hasResult(run *Run, test, action string) (bool, string) {
+	results := run.results(test)
+	if len(results) == 0 {
+		return false, noResult(run, test)
+	}
+	for _, e := range results {
+		if e.Action != action {
+			return false, failure(run, test, "%s %s, not %s", describe(test), finished[e.Action], finished[action])
+		}
+	}
+	return true, ""
+}
+
+// results returns the events that end the test, one for each package it ran in.
+func (run *Run) results(test string) []Event {
+	var results []Event
+	for _, e := range run.Events {
+		if e.Test != test {
+			continue
+		}
+		switch e.Action {
+		case "pass", "fail", "skip":
+			results = append(results, e)
+		}
+	}
+	return results
+}
+
+func (run *Run) output(test string) string {
+	var out strings.Builder
+	for _, e := range run.Events {
+		if e.Test == test && e.Action == "output" {
+			out.WriteString(e.Output)
+		}
+	}
+	return out.String()
+}
+
+// finished describes how a test finished, given the action of the event ending it.
+var finished = map[string]string{"pass": "passed", "fail": "failed", "skip": "skipped"}
+
+func describe(test string) string {
+	if test == "" {
+		return "package"
+	}
+	return fmt.Sprintf("test %q", test)
+}
+
+func noResult(run *Run, test string) string {
+	return fmt.Sprintf("got no result for %s, the tests in the run are %q", describe(test), run.Tests())
+}
+
+func failure(run *Run, test, msgFmt string, args ...any) string {
+	msg := fmt.Sprintf(msgFmt, args...)
+
+	out := run.output(test)
+	if out == "" {
+		return msg
+	}
+	if len(out) > MaxOutputLength {
+		out = out[:MaxOutputLength] + "..."
+	}
+	return fmt.Sprintf("%s\n\noutput:\n%s", msg, out)
+}
@@ -0,0 +1,211 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thetestjson_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/assertions/thetestjson"
+)
+
+func load(t *testing.T) *thetestjson.Run {
+	t.Helper()
+	f, err := os.Open("testdata/run.json")
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	defer f.Close()
+
+	run, err := thetestjson.Parse(f)
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	return run
+}
+
+func TestParse(t *testing.T) {
+
+	t.Run("Valid", func(t *testing.T) {
+		// given
+		f, err := os.Open("testdata/run.json")
+		assert.Using(t.Fatalf).That(theerr.IsNil(err))
+		defer f.Close()
+
+		// when
+		run, err := thetestjson.Parse(f)
+
+		// then
+		assert.Using(t.Fatalf).That(theerr.IsNil(err))
+		assert.Using(t.Errorf).
+			That(theslice.Length(run.Events, 27)).
+			That(theval.Equal(run.Events[3], thetestjson.Event{
+				Time:    time.Date(2023, 6, 1, 12, 0, 33, 139055973, time.UTC),
+				Action:  "output",
+				Package: "example.com/sample",
+				Test:    "TestAdd",
+				Output:  "    sample_test.go:6: adding\n",
+			})).
+			That(theslice.Equal(run.Tests(), []string{"TestAdd", "TestParse", "TestParse/Empty", "TestParse/Number", "TestNetwork"}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// given
+		in := `{"Action":"run","Test":"TestA"}` + "\n" + `FAIL` + "\n"
+
+		// when
+		_, err := thetestjson.Parse(strings.NewReader(in))
+
+		// then
+		assert.Using(t.Fatalf).That(err != nil, "got no error")
+		assert.Using(t.Errorf).That(theval.Equal(err.Error(), "thetestjson: event 2: invalid character 'F' looking for beginning of value"))
+	})
+
+}
+
+func TestResults(t *testing.T) {
+	run := load(t)
+
+	testCases := []struct {
+		Name      string
+		Assertion func(run *thetestjson.Run) (bool, string)
+		Want      string
+	}{
+		{
+			Name:      "Passed/True",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.Passed(run, "TestAdd") },
+		},
+		{
+			Name:      "Passed/Subtest",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return run.Passed("TestParse/Number") },
+		},
+		{
+			Name:      "Passed/False",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.Passed(run, "TestParse/Empty") },
+			Want: "test \"TestParse/Empty\" failed, not passed\n\noutput:\n" +
+				"=== RUN   TestParse/Empty\n" +
+				"    sample_test.go:10: unexpected EOF\n" +
+				"--- FAIL: TestParse/Empty (0.00s)\n",
+		},
+		{
+			Name:      "Passed/Missing",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.Passed(run, "TestMissing") },
+			Want:      `got no result for test "TestMissing", the tests in the run are ["TestAdd" "TestParse" "TestParse/Empty" "TestParse/Number" "TestNetwork"]`,
+		},
+		{
+			Name:      "Failed/True",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.Failed(run, "TestParse") },
+		},
+		{
+			Name:      "Failed/Package",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return run.Failed("") },
+		},
+		{
+			Name:      "Failed/False",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.Failed(run, "TestNetwork") },
+			Want: "test \"TestNetwork\" skipped, not failed\n\noutput:\n" +
+				"=== RUN   TestNetwork\n" +
+				"    sample_test.go:15: no network\n" +
+				"--- SKIP: TestNetwork (0.00s)\n",
+		},
+		{
+			Name:      "Skipped/True",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return run.Skipped("TestNetwork") },
+		},
+		{
+			Name:      "Skipped/False",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.Skipped(run, "") },
+			Want:      "package failed, not skipped\n\noutput:\nFAIL\nFAIL\texample.com/sample\t0.003s\n",
+		},
+		{
+			Name:      "OutputContains/True",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return thetestjson.OutputContains(run, "TestAdd", "adding") },
+		},
+		{
+			Name:      "OutputContains/False",
+			Assertion: func(run *thetestjson.Run) (bool, string) { return run.OutputContains("TestAdd", "subtracting") },
+			Want: "output of test \"TestAdd\" does not contain \"subtracting\"\n\noutput:\n" +
+				"=== RUN   TestAdd\n" +
+				"    sample_test.go:6: adding\n" +
+				"--- PASS: TestAdd (0.00s)\n",
+		},
+		{
+			Name: "ElapsedLessThan/True",
+			Assertion: func(run *thetestjson.Run) (bool, string) {
+				return thetestjson.ElapsedLessThan(run, "TestAdd", time.Second)
+			},
+		},
+		{
+			Name: "ElapsedLessThan/False",
+			Assertion: func(run *thetestjson.Run) (bool, string) {
+				return run.ElapsedLessThan("TestParse", time.Second)
+			},
+			Want: `test "TestParse" took 1.5s, not less than 1s`,
+		},
+		{
+			Name: "ElapsedLessThan/Missing",
+			Assertion: func(run *thetestjson.Run) (bool, string) {
+				return thetestjson.ElapsedLessThan(&thetestjson.Run{}, "TestAdd", time.Second)
+			},
+			Want: `got no result for test "TestAdd", the tests in the run are []`,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(tt.Assertion(run))
+
+			// then
+			if tt.Want == "" {
+				assert.Using(t.Errorf).That(errFunc.NotCalled())
+				return
+			}
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(tt.Want))
+		})
+	}
+}
+
+func TestOutputIsTruncated(t *testing.T) {
+	// given
+	defer func(old int) { thetestjson.MaxOutputLength = old }(thetestjson.MaxOutputLength)
+	thetestjson.MaxOutputLength = 10
+
+	run := load(t)
+
+	// when
+	_, msg := thetestjson.Passed(run, "TestParse/Empty")
+
+	// then
+	assert.Using(t.Errorf).That(theval.Equal(msg, "test \"TestParse/Empty\" failed, not passed\n\noutput:\n=== RUN   ..."))
+}
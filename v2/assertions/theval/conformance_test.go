@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theval_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestConformance(t *testing.T) {
+
+	t.Run("Equal", func(t *testing.T) {
+		p := assertiontesting.PairOf[string, string]
+		assertiontesting.Conformance[assertiontesting.Pair[string, string]]{
+			Assertion: assertiontesting.OnPair(theval.Equal[string]),
+			Not:       assertiontesting.OnPair(theval.NotEqual[string]),
+			Pass:      []assertiontesting.Pair[string, string]{p("", ""), p("a", "a")},
+			Fail:      []assertiontesting.Pair[string, string]{p("a", ""), p("a", "b")},
+		}.Run(t)
+	})
+
+	t.Run("LessThan", func(t *testing.T) {
+		p := assertiontesting.PairOf[int, int]
+		assertiontesting.Conformance[assertiontesting.Pair[int, int]]{
+			Assertion: assertiontesting.OnPair(theval.LessThan[int]),
+			Pass:      []assertiontesting.Pair[int, int]{p(0, 1), p(-5, 5)},
+			Fail:      []assertiontesting.Pair[int, int]{p(1, 1), p(2, 1)},
+		}.Run(t)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		assertiontesting.Conformance[any]{
+			Assertion: theval.Zero,
+			Not:       theval.NotZero,
+			Pass:      []any{0, "", struct{ X int }{}, []int(nil)},
+			Fail:      []any{1, "a", struct{ X int }{1}, []int{}},
+		}.Run(t)
+	})
+
+}
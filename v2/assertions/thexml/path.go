@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thexml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HasElement asserts that doc has an element at path.
+//
+// A path is a list of element names, each preceded by a slash, like /order/items/item.
+// The first name is the name of the root element.
+// A name can be followed by a one-based position in brackets, like item[2].
+// Without a position the first element with the name is selected.
+// Names are matched against the local names of elements, ignoring namespaces.
+func HasElement[D Text](doc D, path string) (bool, string) {
+	_, ok, msg := find(doc, path)
+	return ok, msg
+}
+
+// AttributeEquals asserts that the element at path in doc has the attribute attr with the wanted value.
+//
+// The attribute is matched by its local name, ignoring its namespace.
+// For the syntax of paths see HasElement.
+func AttributeEquals[D Text](doc D, path, attr, want string) (bool, string) {
+	e, ok, msg := find(doc, path)
+	if !ok {
+		return false, msg
+	}
+
+	for _, a := range e.attrs {
+		if a.Name.Local != attr {
+			continue
+		}
+		if a.Value == want {
+			return true, ""
+		}
+		return false, fmt.Sprintf("%s/@%s: got %q, not %q", path, attr, a.Value, want)
+	}
+	return false, fmt.Sprintf("%s/@%s: missing, want %q", path, attr, want)
+}
+
+func find[D Text](doc D, path string) (*element, bool, string) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, false, err.Error()
+	}
+
+	root, err := parse(doc)
+	if err != nil {
+		return nil, false, fmt.Sprintf("got invalid XML %q: %s", doc, err)
+	}
+
+	e, err := lookup(root, steps)
+	if err != nil {
+		return nil, false, fmt.Sprintf("no element at path %s: %s", path, err)
+	}
+	return e, true, ""
+}
+
+type pathStep struct {
+	raw  string
+	name string
+	pos  int
+}
+
+func parsePath(path string) ([]pathStep, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid path %q: must start with /", path)
+	}
+
+	parts := strings.Split(path[1:], "/")
+	steps := make([]pathStep, len(parts))
+	for i, p := range parts {
+		s := pathStep{raw: p, name: p, pos: 1}
+
+		if open := strings.IndexByte(p, '['); open >= 0 {
+			if !strings.HasSuffix(p, "]") {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket in %q", path, p)
+			}
+
+			pos, err := strconv.Atoi(p[open+1 : len(p)-1])
+			if err != nil || pos < 1 {
+				return nil, fmt.Errorf("invalid path %q: invalid position in %q", path, p)
+			}
+			s = pathStep{raw: p, name: p[:open], pos: pos}
+		}
+
+		if s.name == "" {
+			return nil, fmt.Errorf("invalid path %q: empty element name", path)
+		}
+		steps[i] = s
+	}
+	return steps, nil
+}
+
+func lookup(root *element, steps []pathStep) (*element, error) {
+	if root.name.Local != steps[0].name || steps[0].pos != 1 {
+		return nil, fmt.Errorf("root element is <%s>", root.name.Local)
+	}
+
+	e, path := root, "/"+root.name.Local
+	for _, s := range steps[1:] {
+		next, count := (*element)(nil), 0
+		for _, c := range e.children {
+			if c.name.Local != s.name {
+				continue
+			}
+			count++
+			if count == s.pos {
+				next = c
+				break
+			}
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("%s has %d child elements named <%s>", path, count, s.name)
+		}
+		e, path = next, path+"/"+s.raw
+	}
+	return e, nil
+}
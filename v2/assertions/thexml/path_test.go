@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thexml_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thexml"
+)
+
+const pathDoc = `
+<s:order xmlns:s="urn:shop" id="7">
+	<s:item sku="A-1"/>
+	<s:item sku="B-2"/>
+</s:order>`
+
+func TestHasElement(t *testing.T) {
+
+	okCases := []string{
+		"/order",
+		"/order/item",
+		"/order/item[2]",
+	}
+
+	for _, path := range okCases {
+		path := path
+
+		t.Run("True"+path, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thexml.HasElement(pathDoc, path))
+
+			// then
+			assert.Using(t.Errorf).That(errFunc.NotCalled())
+		})
+	}
+
+	oopsCases := []struct {
+		Path    string
+		Message string
+	}{
+		{
+			Path:    "/order/item[3]",
+			Message: "no element at path /order/item[3]: /order has 2 child elements named <item>",
+		},
+		{
+			Path:    "/order/item/note",
+			Message: "no element at path /order/item/note: /order/item has 0 child elements named <note>",
+		},
+		{
+			Path:    "/invoice",
+			Message: "no element at path /invoice: root element is <order>",
+		},
+		{
+			Path:    "order",
+			Message: `invalid path "order": must start with /`,
+		},
+		{
+			Path:    "/order/item[0]",
+			Message: `invalid path "/order/item[0]": invalid position in "item[0]"`,
+		},
+	}
+
+	for _, tt := range oopsCases {
+		tt := tt
+
+		t.Run("False"+tt.Path, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thexml.HasElement(pathDoc, tt.Path))
+
+			// then
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(tt.Message))
+		})
+	}
+
+}
+
+func TestAttributeEquals(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thexml.AttributeEquals(pathDoc, "/order/item[2]", "sku", "B-2"))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False/Value", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thexml.AttributeEquals(pathDoc, "/order", "id", "8"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`/order/@id: got "7", not "8"`))
+	})
+
+	t.Run("False/Missing", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(thexml.AttributeEquals(pathDoc, "/order/item", "qty", "1"))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`/order/item/@qty: missing, want "1"`))
+	})
+
+}
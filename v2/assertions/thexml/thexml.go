@@ -0,0 +1,264 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package thexml provides reusable assertions about XML documents.
+//
+// Documents are compared by their structure rather than their text.
+// Whitespace around and inside text is collapsed, attribute order does not matter,
+// and namespaces are compared by their URIs instead of the prefixes bound to them.
+// Differences are reported by the path of the element they occur at, like /order/item[2]/@id.
+package thexml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// Text is an XML document in textual form.
+type Text interface{ ~[]byte | ~string }
+
+// Equal asserts that an actual XML document is equivalent to an expected one.
+func Equal[G, W Text](got G, want W) (bool, string) {
+	wantRoot, err := parse(want)
+	if err != nil {
+		return false, fmt.Sprintf("invalid wanted XML %q: %s", want, err)
+	}
+
+	gotRoot, err := parse(got)
+	if err != nil {
+		return false, fmt.Sprintf("got invalid XML %q: %s", got, err)
+	}
+
+	diffs := compare("/"+wantRoot.name.Local, gotRoot, wantRoot, nil)
+	if len(diffs) == 0 {
+		return true, ""
+	}
+
+	msg := fmt.Sprintf(
+		"got XML %s, not %s: %s",
+		gotRoot, wantRoot, strings.Join(diffs, "; "))
+	return false, msg
+}
+
+type element struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*element
+	text     string
+}
+
+func (e *element) String() string {
+	var b strings.Builder
+	e.render(&b, "")
+	return b.String()
+}
+
+func (e *element) render(b *strings.Builder, parentSpace string) {
+	b.WriteString("<" + e.name.Local)
+	if e.name.Space != parentSpace {
+		fmt.Fprintf(b, " xmlns=%q", e.name.Space)
+	}
+	for _, a := range e.attrs {
+		b.WriteString(" " + attrName(a.Name) + `="`)
+		xml.EscapeText(b, []byte(a.Value))
+		b.WriteString(`"`)
+	}
+	b.WriteString(">")
+
+	xml.EscapeText(b, []byte(e.text))
+	for _, c := range e.children {
+		c.render(b, e.name.Space)
+	}
+
+	b.WriteString("</" + e.name.Local + ">")
+}
+
+func compare(path string, got, want *element, diffs []string) []string {
+	if got.name != want.name {
+		return append(diffs, fmt.Sprintf(
+			"%s: got element %s, not %s",
+			path, elemName(got.name), elemName(want.name)))
+	}
+
+	diffs = compareAttrs(path, got.attrs, want.attrs, diffs)
+
+	if got.text != want.text {
+		diffs = append(diffs, fmt.Sprintf("%s: got text %q, not %q", path, got.text, want.text))
+	}
+
+	if len(got.children) != len(want.children) {
+		return append(diffs, fmt.Sprintf(
+			"%s: got %d child elements, not %d",
+			path, len(got.children), len(want.children)))
+	}
+
+	for i := range want.children {
+		childPath := path + "/" + step(want.children, i)
+		diffs = compare(childPath, got.children[i], want.children[i], diffs)
+	}
+	return diffs
+}
+
+func compareAttrs(path string, got, want []xml.Attr, diffs []string) []string {
+	for _, w := range want {
+		g, ok := findAttr(got, w.Name)
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: missing, want %q", path, attrName(w.Name), w.Value))
+		case g.Value != w.Value:
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: got %q, not %q", path, attrName(w.Name), g.Value, w.Value))
+		}
+	}
+
+	for _, g := range got {
+		if _, ok := findAttr(want, g.Name); !ok {
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: unexpected %q", path, attrName(g.Name), g.Value))
+		}
+	}
+	return diffs
+}
+
+func findAttr(attrs []xml.Attr, name xml.Name) (xml.Attr, bool) {
+	for _, a := range attrs {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return xml.Attr{}, false
+}
+
+// step returns the path step selecting the i-th element of siblings.
+//
+// The step contains a one-based position only when there are multiple siblings with the same name.
+func step(siblings []*element, i int) string {
+	name := siblings[i].name.Local
+
+	pos, count := 0, 0
+	for j, s := range siblings {
+		if s.name.Local != name {
+			continue
+		}
+		count++
+		if j <= i {
+			pos++
+		}
+	}
+
+	if count == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s[%d]", name, pos)
+}
+
+func elemName(n xml.Name) string {
+	if n.Space == "" {
+		return "<" + n.Local + ">"
+	}
+	return fmt.Sprintf("<%s xmlns=%q>", n.Local, n.Space)
+}
+
+func attrName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return "{" + n.Space + "}" + n.Local
+}
+
+func parse[D Text](doc D) (*element, error) {
+	dec := xml.NewDecoder(bytes.NewReader([]byte(doc)))
+
+	var (
+		root  *element
+		stack []*element
+		texts []*strings.Builder
+	)
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+
+		case xml.StartElement:
+			if root != nil && len(stack) == 0 {
+				return nil, errors.New("multiple root elements")
+			}
+
+			e := &element{name: tok.Name, attrs: normalizeAttrs(tok.Attr)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else {
+				root = e
+			}
+			stack = append(stack, e)
+			texts = append(texts, new(strings.Builder))
+
+		case xml.EndElement:
+			e, text := stack[len(stack)-1], texts[len(texts)-1]
+			e.text = strings.Join(strings.Fields(text.String()), " ")
+			stack, texts = stack[:len(stack)-1], texts[:len(texts)-1]
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				texts[len(texts)-1].Write(tok)
+			} else if len(bytes.TrimSpace(tok)) > 0 {
+				return nil, errors.New("text outside of the root element")
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+// normalizeAttrs drops namespace declarations and sorts the remaining attributes.
+func normalizeAttrs(attrs []xml.Attr) []xml.Attr {
+	out := make([]xml.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+		out = append(out, a)
+	}
+
+	slices.SortFunc(out, func(a, b xml.Attr) bool {
+		if a.Name.Space != b.Name.Space {
+			return a.Name.Space < b.Name.Space
+		}
+		return a.Name.Local < b.Name.Local
+	})
+	return out
+}
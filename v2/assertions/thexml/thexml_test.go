@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package thexml_test
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/assertions/thexml"
+)
+
+func TestEqual(t *testing.T) {
+
+	okCases := []struct {
+		Name      string
+		Got, Want string
+	}{
+		{
+			Name: "Whitespace",
+			Got:  "<order>\n  <item>  two\n words </item>\n</order>",
+			Want: `<order><item>two words</item></order>`,
+		},
+		{
+			Name: "AttributeOrder",
+			Got:  `<order id="1" status="new"/>`,
+			Want: `<order status="new" id="1"></order>`,
+		},
+		{
+			Name: "NamespacePrefixes",
+			Got:  `<a:order xmlns:a="urn:shop" xmlns:x="urn:ext" x:ref="7"><a:item/></a:order>`,
+			Want: `<order xmlns="urn:shop" xmlns:e="urn:ext" e:ref="7"><item/></order>`,
+		},
+		{
+			Name: "Declaration",
+			Got:  `<?xml version="1.0"?><!-- note --><order/>`,
+			Want: `<order/>`,
+		},
+	}
+
+	for _, tt := range okCases {
+		tt := tt
+
+		t.Run("True/"+tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thexml.Equal(tt.Got, tt.Want))
+
+			// then
+			assert.Using(t.Errorf).That(errFunc.NotCalled())
+		})
+	}
+
+	oopsCases := []struct {
+		Name      string
+		Got, Want string
+		Message   string
+	}{
+		{
+			Name:    "Text",
+			Got:     `<order><item>a</item></order>`,
+			Want:    `<order><item>b</item></order>`,
+			Message: `got XML <order><item>a</item></order>, not <order><item>b</item></order>: /order/item: got text "a", not "b"`,
+		},
+		{
+			Name: "Attributes",
+			Got:  `<order id="1" extra="x"/>`,
+			Want: `<order id="2" status="new"/>`,
+			Message: `got XML <order extra="x" id="1"></order>, not <order id="2" status="new"></order>: ` +
+				`/order/@id: got "1", not "2"; /order/@status: missing, want "new"; /order/@extra: unexpected "x"`,
+		},
+		{
+			Name: "RepeatedElements",
+			Got:  `<order><item id="1"/><item id="2"/><note/></order>`,
+			Want: `<order><item id="1"/><item id="3"/><note/></order>`,
+			Message: `got XML <order><item id="1"></item><item id="2"></item><note></note></order>, ` +
+				`not <order><item id="1"></item><item id="3"></item><note></note></order>: ` +
+				`/order/item[2]/@id: got "2", not "3"`,
+		},
+		{
+			Name:    "ChildCount",
+			Got:     `<order><item/></order>`,
+			Want:    `<order><item/><item/></order>`,
+			Message: `got XML <order><item></item></order>, not <order><item></item><item></item></order>: /order: got 1 child elements, not 2`,
+		},
+		{
+			Name: "Namespace",
+			Got:  `<order xmlns="urn:a"/>`,
+			Want: `<order xmlns="urn:b"/>`,
+			Message: `got XML <order xmlns="urn:a"></order>, not <order xmlns="urn:b"></order>: ` +
+				`/order: got element <order xmlns="urn:a">, not <order xmlns="urn:b">`,
+		},
+		{
+			Name:    "InvalidGot",
+			Got:     `<order>`,
+			Want:    `<order/>`,
+			Message: `got invalid XML "<order>": XML syntax error on line 1: unexpected EOF`,
+		},
+		{
+			Name:    "InvalidWant",
+			Got:     `<order/>`,
+			Want:    `<a/><b/>`,
+			Message: `invalid wanted XML "<a/><b/>": multiple root elements`,
+		},
+	}
+
+	for _, tt := range oopsCases {
+		tt := tt
+
+		t.Run("False/"+tt.Name, func(t *testing.T) {
+			// given
+			var errFunc assertiontesting.ErrFunc
+
+			// when
+			assert.Using(errFunc.Record).That(thexml.Equal([]byte(tt.Got), tt.Want))
+
+			// then
+			assert.Using(t.Errorf).
+				That(errFunc.Called()).
+				That(errFunc.MessageFormatsTo(tt.Message))
+		})
+	}
+
+}
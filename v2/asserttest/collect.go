@@ -0,0 +1,170 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package asserttest
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/szabba/assert/v2/internal/caller"
+)
+
+// A Collector gathers assertion failures from many goroutines and reports them on the test goroutine.
+//
+// The testing package only allows FailNow to be called from the goroutine running the test.
+// Start the other goroutines using Go, and use the Errorf and Fatalf methods of the Collector they receive as ErrorFuncs:
+//
+//	c := asserttest.Collect(t)
+//	c.Go(func(c *asserttest.Collector) {
+//	    assert.Using(c.Fatalf).That(theerr.IsNil(work()))
+//	})
+//	c.Wait()
+//
+// The failures are buffered and replayed in order, when Wait is called and when the test is cleaned up.
+// Each replayed failure names the location it came from, and the goroutine when it was started using Go.
+//
+// A Collector is safe for concurrent use.
+type Collector struct {
+	*collection
+
+	// goroutine numbers the goroutines started using Go from 1.
+	// It is 0 for the Collector of the test goroutine.
+	goroutine int
+}
+
+type collection struct {
+	t  testing.TB
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	started  int
+	failures []collected
+}
+
+type collected struct {
+	goroutine int
+	location  string
+	msg       string
+	fatal     bool
+}
+
+// Collect creates a Collector that reports failures to t.
+//
+// Any failures not yet reported when the test finishes are reported during its cleanup.
+func Collect(t testing.TB) *Collector {
+	c := &Collector{collection: &collection{t: t}}
+	t.Cleanup(c.Wait)
+	return c
+}
+
+// Errorf records a failure that will be reported using the Errorf method of the test.
+//
+// It is an ErrorFunc that can be called from any goroutine.
+func (c *Collector) Errorf(msgFmt string, args ...any) {
+	c.record(false, msgFmt, args)
+}
+
+// Fatalf records a failure that will be reported using the Errorf method of the test, and then stops it using FailNow.
+//
+// It is an ErrorFunc, that like testing.T.Fatalf stops the goroutine calling it.
+// On a Collector received from Go, it stops the goroutine using runtime.Goexit, and the test is stopped by Wait.
+// Otherwise, it must be called from the goroutine running the test.
+// It then reports the failures recorded so far and stops the test right away.
+func (c *Collector) Fatalf(msgFmt string, args ...any) {
+	c.t.Helper()
+	c.record(true, msgFmt, args)
+	if c.goroutine > 0 {
+		runtime.Goexit()
+	}
+	c.report()
+}
+
+// Go runs f in a new goroutine that Wait waits for.
+//
+// f receives a Collector for that goroutine, which shares the failures with c.
+func (c *Collector) Go(f func(c *Collector)) {
+	c.mu.Lock()
+	c.started++
+	g := &Collector{collection: c.collection, goroutine: c.started}
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		f(g)
+	}()
+}
+
+// Wait waits for the goroutines started using Go and reports the failures recorded so far.
+//
+// The failures are reported in the order they were recorded.
+// If any of them was recorded using Fatalf, Wait stops the test using FailNow after reporting all of them.
+//
+// Wait must be called from the goroutine running the test.
+func (c *Collector) Wait() {
+	c.t.Helper()
+	c.wg.Wait()
+	c.report()
+}
+
+// report reports the failures recorded so far, and stops the test when any of them was fatal.
+func (c *collection) report() {
+	c.t.Helper()
+
+	c.mu.Lock()
+	failures := c.failures
+	c.failures = nil
+	c.mu.Unlock()
+
+	fatal := false
+	for _, f := range failures {
+		if f.goroutine > 0 {
+			c.t.Errorf("goroutine #%d, %s: %s", f.goroutine, f.location, f.msg)
+		} else {
+			c.t.Errorf("%s: %s", f.location, f.msg)
+		}
+		fatal = fatal || f.fatal
+	}
+	if fatal {
+		c.t.FailNow()
+	}
+}
+
+func (c *Collector) record(fatal bool, msgFmt string, args []any) {
+	f := collected{
+		goroutine: c.goroutine,
+		location:  "unknown location",
+		msg:       fmt.Sprintf(msgFmt, args...),
+		fatal:     fatal,
+	}
+	if frame, ok := caller.Frame(); ok {
+		f.location = fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, f)
+}
@@ -0,0 +1,171 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package asserttest_test
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+
+	"github.com/szabba/assert/v2/asserttest"
+)
+
+func TestCollectorReplaysFailuresFromOtherGoroutines(t *testing.T) {
+	// given
+	tb := &collectingTB{TB: t}
+	c := asserttest.Collect(tb)
+
+	// when
+	c.Go(func(c *asserttest.Collector) { assert.Using(c.Errorf).That(false, "first: %d", 1) })
+	c.Wait()
+	c.Go(func(c *asserttest.Collector) { assert.Using(c.Errorf).That(false, "second: %d", 2) })
+	c.Wait()
+
+	// then
+	want := []*regexp.Regexp{
+		regexp.MustCompile(`^goroutine #1, collect_test.go:\d+: first: 1$`),
+		regexp.MustCompile(`^goroutine #2, collect_test.go:\d+: second: 2$`),
+	}
+	if len(tb.errors) != len(want) {
+		t.Fatalf("got errors %q, not %d errors", tb.errors, len(want))
+	}
+	for i, re := range want {
+		if !re.MatchString(tb.errors[i]) {
+			t.Errorf("got error %q, not matching %s", tb.errors[i], re)
+		}
+	}
+	if tb.failedNow {
+		t.Error("the test was stopped")
+	}
+}
+
+func TestCollectorReportsAssertionSiteThroughMiddleware(t *testing.T) {
+	// given
+	tb := &collectingTB{TB: t}
+	c := asserttest.Collect(tb)
+
+	// when
+	_, _, line, _ := runtime.Caller(0)
+	assert.Using(c.Errorf).Use(prefix("wrapped")).That(false, "Oops")
+	c.Wait()
+
+	// then
+	want := fmt.Sprintf("collect_test.go:%d: wrapped: Oops", line+1)
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("got errors %q, not just %q", tb.errors, want)
+	}
+}
+
+func TestCollectorFatalfStopsTestRightAwayOnTestGoroutine(t *testing.T) {
+	// given
+	tb := &collectingTB{TB: t}
+	c := asserttest.Collect(tb)
+	assert.Using(c.Errorf).That(false, "first")
+
+	// when
+	assert.Using(c.Fatalf).That(false, "fatal")
+
+	// then
+	if len(tb.errors) != 2 || !strings.HasSuffix(tb.errors[0], "first") || !strings.HasSuffix(tb.errors[1], "fatal") {
+		t.Errorf("got errors %q, not the first and fatal ones", tb.errors)
+	}
+	if !tb.failedNow {
+		t.Error("the test was not stopped")
+	}
+}
+
+func TestCollectorFatalfStopsGoroutinesStartedWithGo(t *testing.T) {
+	// given
+	tb := &collectingTB{TB: t}
+	c := asserttest.Collect(tb)
+	continued := false
+
+	// when
+	c.Go(func(c *asserttest.Collector) {
+		assert.Using(c.Fatalf).That(false, "fatal")
+		continued = true
+	})
+	c.Wait()
+
+	// then
+	if continued {
+		t.Error("the goroutine continued after the fatal failure")
+	}
+	if len(tb.errors) != 1 {
+		t.Errorf("got errors %q, not 1 error", tb.errors)
+	}
+	if !tb.failedNow {
+		t.Error("the test was not stopped")
+	}
+}
+
+func TestCollectorReportsRemainingFailuresOnCleanup(t *testing.T) {
+	// given
+	tb := &collectingTB{TB: t}
+	c := asserttest.Collect(tb)
+
+	// when
+	assert.Using(c.Errorf).That(false, "not waited for")
+	tb.cleanup()
+
+	// then
+	if len(tb.errors) != 1 {
+		t.Errorf("got errors %q, not 1 error", tb.errors)
+	}
+}
+
+// collectingTB records the failures reported to it instead of failing the test.
+type collectingTB struct {
+	testing.TB
+	errors    []string
+	failedNow bool
+	cleanups  []func()
+}
+
+func (tb *collectingTB) Helper() {}
+
+func (tb *collectingTB) Errorf(msgFmt string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(msgFmt, args...))
+}
+
+func (tb *collectingTB) FailNow() { tb.failedNow = true }
+
+func (tb *collectingTB) Cleanup(f func()) { tb.cleanups = append(tb.cleanups, f) }
+
+func (tb *collectingTB) cleanup() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+func prefix(p string) assert.Middleware {
+	return func(next assert.ErrorFunc) assert.ErrorFunc {
+		return func(msgFmt string, args ...any) {
+			next(p+": "+msgFmt, args...)
+		}
+	}
+}
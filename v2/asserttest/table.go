@@ -0,0 +1,160 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package asserttest provides helpers for using assertions in tests.
+//
+// It is kept apart from the assert package, so that programs using assertions outside of tests
+// do not link the testing package.
+package asserttest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+// A TableOption changes how Table runs the cases.
+type TableOption func(*tableConfig)
+
+type tableConfig struct {
+	parallel  bool
+	nameField string
+}
+
+// Parallel makes Table run the cases in parallel with each other.
+func Parallel() TableOption {
+	return func(cfg *tableConfig) { cfg.parallel = true }
+}
+
+// NameField makes Table name the subtests using the field of the case with the given name.
+func NameField(field string) TableOption {
+	return func(cfg *tableConfig) { cfg.nameField = field }
+}
+
+// Table runs f for each of the cases in a separate subtest of t.
+//
+// Each subtest is named after its case.
+// Cases that implement fmt.Stringer are named using their String method.
+// Otherwise the Name field of the case struct is used, unless another field is picked using NameField.
+// Cases that have neither are named after their position in cases.
+//
+// f receives an Asserter that reports failures using the Errorf method of the subtest.
+// The failure messages are labeled with the position and name of the case.
+//
+// A case struct can have boolean Only and Skip fields.
+// When any case has Only set, all the cases without it are skipped.
+// Cases with Skip set are always skipped.
+//
+// After all the cases have run, Table logs a summary of the cases that failed.
+func Table[C any](t *testing.T, cases []C, f func(a assert.Asserter, c C), opts ...TableOption) {
+	t.Helper()
+
+	cfg := tableConfig{nameField: "Name"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	only := false
+	for _, c := range cases {
+		only = only || caseFlag(c, "Only")
+	}
+
+	var mu sync.Mutex
+	var failed []string
+	t.Cleanup(func() {
+		if len(failed) > 0 {
+			t.Logf("%d of %d cases failed:\n\t%s", len(failed), len(cases), strings.Join(failed, "\n\t"))
+		}
+	})
+
+	for i, c := range cases {
+		i, c := i, c
+		name := caseName(c, i, cfg.nameField)
+		label := fmt.Sprintf("case #%d %q", i, name)
+
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			switch {
+			case caseFlag(c, "Skip"):
+				t.Skip("case marked Skip")
+			case only && !caseFlag(c, "Only"):
+				t.Skip("another case is marked Only")
+			}
+
+			t.Cleanup(func() {
+				if t.Failed() {
+					mu.Lock()
+					defer mu.Unlock()
+					failed = append(failed, label)
+				}
+			})
+
+			if cfg.parallel {
+				t.Parallel()
+			}
+
+			f(assert.Using(labeled(t.Errorf, label)), c)
+		})
+	}
+}
+
+// labeled creates an ErrorFunc that prefixes the messages passed to onErr with the label.
+func labeled(onErr assert.ErrorFunc, label string) assert.ErrorFunc {
+	return func(msgFmt string, args ...any) {
+		onErr("%s: "+msgFmt, append([]any{label}, args...)...)
+	}
+}
+
+func caseName(c any, i int, field string) string {
+	if s, ok := c.(fmt.Stringer); ok {
+		return s.String()
+	}
+	if f, ok := caseField(c, field); ok && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return fmt.Sprintf("#%02d", i)
+}
+
+func caseFlag(c any, field string) bool {
+	f, ok := caseField(c, field)
+	return ok && f.Kind() == reflect.Bool && f.Bool()
+}
+
+func caseField(c any, field string) (reflect.Value, bool) {
+	v := reflect.ValueOf(c)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	f := v.FieldByName(field)
+	return f, f.IsValid()
+}
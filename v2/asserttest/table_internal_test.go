@@ -0,0 +1,70 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package asserttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+type stringerCase struct{ Name string }
+
+func (c stringerCase) String() string { return "stringer " + c.Name }
+
+func TestCaseName(t *testing.T) {
+	type titled struct{ Title string }
+
+	for _, tt := range []struct {
+		c     any
+		field string
+		want  string
+	}{
+		{struct{ Name string }{"by field"}, "Name", "by field"},
+		{&struct{ Name string }{"by pointer"}, "Name", "by pointer"},
+		{titled{"other field"}, "Title", "other field"},
+		{stringerCase{"x"}, "Name", "stringer x"},
+		{struct{ Name int }{1}, "Name", "#07"},
+		{42, "Name", "#07"},
+	} {
+		if got := caseName(tt.c, 7, tt.field); got != tt.want {
+			t.Errorf("got name %q for %#v, not %q", got, tt.c, tt.want)
+		}
+	}
+}
+
+func TestLabeled(t *testing.T) {
+	// given
+	var got string
+	onErr := func(msgFmt string, args ...any) { got = fmt.Sprintf(msgFmt, args...) }
+
+	// when
+	assert.Using(labeled(onErr, `case #1 "a"`)).That(false, "got %d, not %d", 1, 2)
+
+	// then
+	want := `case #1 "a": got 1, not 2`
+	if got != want {
+		t.Errorf("got message %q, not %q", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package asserttest_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theslice"
+
+	"github.com/szabba/assert/v2/asserttest"
+)
+
+type tableCase struct {
+	Name       string
+	Only, Skip bool
+}
+
+func TestTableRunsAllCases(t *testing.T) {
+	// given
+	cases := []tableCase{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	// when
+	var ran []string
+	asserttest.Table(t, cases, func(a assert.Asserter, c tableCase) {
+		ran = append(ran, c.Name)
+	})
+
+	// then
+	assert.Using(t.Errorf).That(theslice.Equal(ran, []string{"a", "b", "c"}))
+}
+
+func TestTableSkipsCasesMarkedSkip(t *testing.T) {
+	// given
+	cases := []tableCase{{Name: "a"}, {Name: "b", Skip: true}, {Name: "c"}}
+
+	// when
+	var ran []string
+	asserttest.Table(t, cases, func(a assert.Asserter, c tableCase) {
+		ran = append(ran, c.Name)
+	})
+
+	// then
+	assert.Using(t.Errorf).That(theslice.Equal(ran, []string{"a", "c"}))
+}
+
+func TestTableRunsOnlyCasesMarkedOnly(t *testing.T) {
+	// given
+	cases := []tableCase{{Name: "a"}, {Name: "b", Only: true}, {Name: "c", Only: true, Skip: true}}
+
+	// when
+	var ran []string
+	asserttest.Table(t, cases, func(a assert.Asserter, c tableCase) {
+		ran = append(ran, c.Name)
+	})
+
+	// then
+	assert.Using(t.Errorf).That(theslice.Equal(ran, []string{"b"}))
+}
+
+func TestTableRunsCasesInParallel(t *testing.T) {
+	// given
+	cases := []tableCase{{Name: "a"}, {Name: "b"}}
+
+	var mu sync.Mutex
+	var ran []string
+	var ranBeforeReturn int
+
+	// when
+	t.Run("Table", func(t *testing.T) {
+		asserttest.Table(t, cases, func(a assert.Asserter, c tableCase) {
+			mu.Lock()
+			defer mu.Unlock()
+			ran = append(ran, c.Name)
+		}, asserttest.Parallel())
+
+		// Parallel subtests only start once the parent test function returns.
+		mu.Lock()
+		defer mu.Unlock()
+		ranBeforeReturn = len(ran)
+	})
+
+	// then
+	assert.Using(t.Errorf).
+		That(ranBeforeReturn == 0, "%d cases ran before Table returned", ranBeforeReturn).
+		That(theslice.Length(ran, 2))
+}
+
+func TestTableLogsSummaryOfFailedCases(t *testing.T) {
+	if os.Getenv("ASSERTTEST_FAILING_TABLE") != "" {
+		cases := []tableCase{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		asserttest.Table(t, cases, func(a assert.Asserter, c tableCase) {
+			a.That(c.Name == "b", "not b")
+		})
+		return
+	}
+
+	// given
+	cmd := exec.Command(os.Args[0], "-test.run=^TestTableLogsSummaryOfFailedCases$", "-test.v")
+	cmd.Env = append(os.Environ(), "ASSERTTEST_FAILING_TABLE=1")
+
+	// when
+	out, err := cmd.CombinedOutput()
+
+	// then
+	got := string(out)
+	assert.Using(t.Errorf).
+		That(err != nil, "the table with failing cases passed:\n%s", got).
+		That(strings.Contains(got, "2 of 3 cases failed:"), "no summary in output:\n%s", got).
+		That(strings.Contains(got, "\tcase #0 \"a\"\n"), "case a missing from summary:\n%s", got).
+		That(!strings.Contains(got, "\tcase #1 \"b\"\n"), "case b in summary:\n%s", got).
+		That(strings.Contains(got, "\tcase #2 \"c\"\n"), "case c missing from summary:\n%s", got)
+}
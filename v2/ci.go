@@ -0,0 +1,208 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/szabba/assert/v2/internal/caller"
+)
+
+// Also creates a Middleware that reports failures to f before passing them on.
+//
+// Use it to send failures to a CI sink without changing how they are reported otherwise:
+//
+//	assert.Using(t.Errorf).Use(assert.Also(assert.GitHubActions(os.Stdout)))
+func Also(f ErrorFunc) Middleware {
+	return func(next ErrorFunc) ErrorFunc {
+		return func(msgFmt string, args ...any) {
+			f(msgFmt, args...)
+			next(msgFmt, args...)
+		}
+	}
+}
+
+// GitHubActions creates an ErrorFunc that writes failures to w as GitHub Actions error annotations:
+//
+//	::error file=pkg/x_test.go,line=12::got 1, not 2
+//
+// When w is the standard output of a workflow step, the failures show up inline on the diff of a pull request.
+// The file is made relative to the GITHUB_WORKSPACE environment variable, or the working directory if it is not set.
+func GitHubActions(w io.Writer) ErrorFunc {
+	var mu sync.Mutex
+	return func(msgFmt string, args ...any) {
+		msg := fmt.Sprintf(msgFmt, args...)
+
+		var props string
+		if frame, ok := caller.Frame(); ok {
+			props = fmt.Sprintf(" file=%s,line=%d", escapeProperty(workspacePath(frame.File)), frame.Line)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "::error%s::%s\n", props, escapeData(msg))
+	}
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// workspacePath makes path relative to the CI workspace, if it is inside it.
+func workspacePath(path string) string {
+	base := os.Getenv("GITHUB_WORKSPACE")
+	if base == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return path
+		}
+		base = wd
+	}
+
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// A JUnitReport writes failures to a file in the JUnit XML format.
+//
+// Each failure becomes a test case named after the function that made the assertion.
+// The file is rewritten after every failure, so it is complete even if the program crashes later.
+// Only failures are reported: the file does not list assertions or tests that passed.
+type JUnitReport struct {
+	path  string
+	suite string
+
+	mu       sync.Mutex
+	failures []junitTestCase
+	err      error
+}
+
+// NewJUnitReport creates a JUnitReport writing to the file at path.
+//
+// The suite names the single test suite in the report.
+// The file only gets created once a failure is reported.
+func NewJUnitReport(path, suite string) *JUnitReport {
+	return &JUnitReport{path: path, suite: suite}
+}
+
+// Errorf reports a failure.
+//
+// It is an ErrorFunc.
+// When writing the report fails, the error is available from Err.
+func (r *JUnitReport) Errorf(msgFmt string, args ...any) {
+	msg := fmt.Sprintf(msgFmt, args...)
+
+	tc := junitTestCase{Name: "unknown", Failure: junitFailure{Message: msg, Type: "assertion", Text: msg}}
+	if frame, ok := caller.Frame(); ok {
+		tc.ClassName, tc.Name = splitFunction(frame.Function)
+		tc.File, tc.Line = workspacePath(frame.File), frame.Line
+		tc.Failure.Text = fmt.Sprintf("%s:%d: %s", tc.File, tc.Line, msg)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, tc)
+	if err := r.write(); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// Err returns the first error that happened while writing the report.
+func (r *JUnitReport) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *JUnitReport) write() error {
+	report := junitTestSuites{Suites: []junitTestSuite{{
+		Name:      r.suite,
+		Tests:     len(r.failures),
+		Failures:  len(r.failures),
+		TestCases: r.failures,
+	}}}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	out = append([]byte(xml.Header), append(out, '\n')...)
+	return os.WriteFile(r.path, out, 0o644)
+}
+
+// splitFunction splits the full name of a function into its package path and the name of the top-level function.
+//
+// Closures are attributed to the function they are defined in.
+func splitFunction(function string) (pkg, name string) {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return "", function
+	}
+	pkg, name = function[:slash+1+dot], function[slash+1+dot+1:]
+	if end := strings.Index(name, ".func"); end >= 0 {
+		name = name[:end]
+	}
+	return pkg, name
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string       `xml:"classname,attr"`
+	Name      string       `xml:"name,attr"`
+	File      string       `xml:"file,attr,omitempty"`
+	Line      int          `xml:"line,attr,omitempty"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
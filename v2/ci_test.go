@@ -0,0 +1,140 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+func TestGitHubActionsWritesErrorAnnotations(t *testing.T) {
+	// given
+	_, file, line, _ := runtime.Caller(0)
+	t.Setenv("GITHUB_WORKSPACE", filepath.Dir(filepath.Dir(file)))
+
+	var out bytes.Buffer
+	a := assert.Using(assert.GitHubActions(&out))
+
+	// when
+	a.That(false, "got %d,\nnot %d: 100%%", 1, 2)
+
+	// then
+	want := fmt.Sprintf("::error file=v2/ci_test.go,line=%d::got 1,%%0Anot 2: 100%%25\n", line+7)
+	if got := out.String(); got != want {
+		t.Errorf("got output %q, not %q", got, want)
+	}
+}
+
+func TestGitHubActionsKeepsPathsOutsideTheWorkspace(t *testing.T) {
+	// given
+	t.Setenv("GITHUB_WORKSPACE", t.TempDir())
+
+	var out bytes.Buffer
+	a := assert.Using(assert.GitHubActions(&out))
+
+	// when
+	_, file, line, _ := runtime.Caller(0)
+	a.That(false, "Oops")
+
+	// then
+	want := fmt.Sprintf("::error file=%s,line=%d::Oops\n", file, line+1)
+	if got := out.String(); got != want {
+		t.Errorf("got output %q, not %q", got, want)
+	}
+}
+
+func TestAlsoReportsToBothErrorFuncs(t *testing.T) {
+	// given
+	var out bytes.Buffer
+	var reported string
+	errFunc := func(msgFmt string, args ...any) { reported = fmt.Sprintf(msgFmt, args...) }
+
+	// when
+	assert.Using(errFunc).Use(assert.Also(assert.GitHubActions(&out))).That(false, "Oops")
+
+	// then
+	if reported != "Oops" {
+		t.Errorf("got reported message %q, not %q", reported, "Oops")
+	}
+	if out.Len() == 0 {
+		t.Error("no annotation was written")
+	}
+}
+
+func TestJUnitReportWritesFailures(t *testing.T) {
+	// given
+	_, file, line, _ := runtime.Caller(0)
+	t.Setenv("GITHUB_WORKSPACE", filepath.Dir(file))
+	path := filepath.Join(t.TempDir(), "report.xml")
+
+	report := assert.NewJUnitReport(path, "assertions")
+	a := assert.Using(report.Errorf)
+
+	// when
+	a.That(false, "got %d, not %d", 1, 2)
+	func() { a.That(false, "<nested>") }()
+
+	// then
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading report: %s", err)
+	}
+	want := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="assertions" tests="2" failures="2">
+    <testcase classname="github.com/szabba/assert/v2_test" name="TestJUnitReportWritesFailures" file="ci_test.go" line="%d">
+      <failure message="got 1, not 2" type="assertion">ci_test.go:%d: got 1, not 2</failure>
+    </testcase>
+    <testcase classname="github.com/szabba/assert/v2_test" name="TestJUnitReportWritesFailures" file="ci_test.go" line="%d">
+      <failure message="&lt;nested&gt;" type="assertion">ci_test.go:%d: &lt;nested&gt;</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, line+8, line+8, line+9, line+9)
+	if string(got) != want {
+		t.Errorf("got report\n%s\nnot\n%s", got, want)
+	}
+	if err := report.Err(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestJUnitReportKeepsWriteErrors(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "missing", "report.xml")
+	report := assert.NewJUnitReport(path, "assertions")
+
+	// when
+	assert.Using(report.Errorf).That(false, "Oops")
+
+	// then
+	if err := report.Err(); err == nil {
+		t.Error("got no error")
+	}
+}
@@ -0,0 +1,393 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// How the values of a field get compared.
+const (
+	compareOperator = "operator" // With the == operator.
+	compareMethod   = "method"   // With an Equal method, like the one of time.Time.
+	comparePointer  = "pointer"  // By comparing what the pointers point to.
+	compareSlice    = "slice"    // Element by element.
+	compareMap      = "map"      // Entry by entry.
+)
+
+type model struct {
+	Command   string
+	Package   string
+	Qualified string
+	// The imports are split into the ones from the standard library and the rest.
+	Imports [2][]importSpec
+	Fields  []field
+	Skipped []string
+	Helpers map[string]bool
+}
+
+type importSpec struct {
+	Name, Path string
+}
+
+type field struct {
+	Name    string
+	Type    string
+	Compare string
+}
+
+// Equal is the Go expression comparing got and want.
+func (f field) Equal() string {
+	switch f.Compare {
+	case compareMethod:
+		return "got.Equal(want)"
+	case comparePointer:
+		return "equalPointers(got, want)"
+	case compareSlice:
+		return "equalSlices(got, want)"
+	case compareMap:
+		return "equalMaps(got, want)"
+	}
+	return "got == want"
+}
+
+// generate produces the source of a package with assertions about the named type.
+//
+// The package is called pkgName and the source begins with a comment saying it was generated by command.
+func generate(named *types.Named, pkgName, command string) ([]byte, error) {
+	q := newQualifier(pkgName)
+
+	m := model{
+		Command:   command,
+		Package:   pkgName,
+		Qualified: types.TypeString(named, q.qualify),
+		Helpers:   map[string]bool{},
+	}
+
+	st := named.Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() {
+			m.Skipped = append(m.Skipped, f.Name())
+			continue
+		}
+
+		cmp, ok := comparison(f.Type(), named.Obj().Pkg())
+		if !ok || !nameable(f.Type()) {
+			m.Skipped = append(m.Skipped, f.Name())
+			continue
+		}
+		if reserved[f.Name()] {
+			return nil, fmt.Errorf("field %s clashes with a generated method", f.Name())
+		}
+
+		m.Fields = append(m.Fields, field{
+			Name:    f.Name(),
+			Type:    types.TypeString(f.Type(), q.qualify),
+			Compare: cmp,
+		})
+		m.Helpers[cmp] = true
+	}
+	if len(m.Fields) == 0 {
+		return nil, fmt.Errorf("%s has no fields that can be compared", m.Qualified)
+	}
+	m.Imports = q.imports()
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, m); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// reserved holds the names of the methods of Matcher that fields cannot share.
+var reserved = map[string]bool{"Matches": true, "with": true}
+
+// comparison decides how values of type t can be compared, if at all.
+func comparison(t types.Type, pkg *types.Package) (string, bool) {
+	if hasEqualMethod(t, pkg) {
+		return compareMethod, true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		if types.Comparable(u.Elem()) {
+			return comparePointer, true
+		}
+	case *types.Slice:
+		if types.Comparable(u.Elem()) {
+			return compareSlice, true
+		}
+	case *types.Map:
+		if types.Comparable(u.Elem()) {
+			return compareMap, true
+		}
+	default:
+		if types.Comparable(t) {
+			return compareOperator, true
+		}
+	}
+	return "", false
+}
+
+// hasEqualMethod tells whether t has a method like func (t T) Equal(other T) bool.
+func hasEqualMethod(t types.Type, pkg *types.Package) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, pkg, "Equal")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	return sig.Params().Len() == 1 && types.Identical(sig.Params().At(0).Type(), t) &&
+		sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+// nameable tells whether t can be written down outside of the package it is declared in.
+func nameable(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		if t.Obj().Pkg() != nil && !t.Obj().Exported() {
+			return false
+		}
+		args := t.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if !nameable(args.At(i)) {
+				return false
+			}
+		}
+	case *types.Pointer:
+		return nameable(t.Elem())
+	case *types.Slice:
+		return nameable(t.Elem())
+	case *types.Array:
+		return nameable(t.Elem())
+	case *types.Map:
+		return nameable(t.Key()) && nameable(t.Elem())
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if !t.Field(i).Exported() || !nameable(t.Field(i).Type()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// A qualifier names the packages types come from and keeps track of the imports needed for that.
+type qualifier struct {
+	names map[string]string // path -> name
+	taken map[string]bool
+}
+
+func newQualifier(pkgName string) *qualifier {
+	q := &qualifier{names: map[string]string{}, taken: map[string]bool{pkgName: true}}
+	q.names["fmt"] = "fmt"
+	q.names["strings"] = "strings"
+	q.taken["fmt"] = true
+	q.taken["strings"] = true
+	return q
+}
+
+func (q *qualifier) qualify(pkg *types.Package) string {
+	if name, ok := q.names[pkg.Path()]; ok {
+		return name
+	}
+	name := pkg.Name()
+	for i := 2; q.taken[name]; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	q.names[pkg.Path()] = name
+	q.taken[name] = true
+	return name
+}
+
+func (q *qualifier) imports() [2][]importSpec {
+	var groups [2][]importSpec
+	for path, name := range q.names {
+		spec := importSpec{Path: path}
+		if name != path[strings.LastIndex(path, "/")+1:] {
+			spec.Name = name
+		}
+
+		group := 0
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			group = 1
+		}
+		groups[group] = append(groups[group], spec)
+	}
+	for _, specs := range groups {
+		sort.Slice(specs, func(i, j int) bool { return specs[i].Path < specs[j].Path })
+	}
+	return groups
+}
+
+var tmpl = template.Must(template.New("assertions").Funcs(template.FuncMap{
+	"join": func(names []string) string { return strings.Join(names, ", ") },
+}).Parse(`// Code generated by {{.Command}}; DO NOT EDIT.
+
+// Package {{.Package}} provides reusable assertions about {{.Qualified}} values.
+package {{.Package}}
+
+import (
+{{- range $i, $group := .Imports}}{{if $i}}
+{{end}}
+{{- range $group}}
+	{{.Name}} "{{.Path}}"
+{{- end}}
+{{- end}}
+)
+
+// Equal asserts that got is equal to want.
+//
+// The fields are compared one by one and the message lists the ones that differ.
+{{- if .Skipped}}
+// The fields {{join .Skipped}} are not compared.
+{{- end}}
+func Equal(got, want {{.Qualified}}) (bool, string) {
+	return result(
+{{- range .Fields}}
+		diff{{.Name}}(got.{{.Name}}, want.{{.Name}}),
+{{- end}}
+	)
+}
+{{range .Fields}}
+// {{.Name}}Equals asserts that the {{.Name}} field of got is equal to want.
+func {{.Name}}Equals(got {{$.Qualified}}, want {{.Type}}) (bool, string) {
+	return result(diff{{.Name}}(got.{{.Name}}, want))
+}
+{{end}}
+// A Matcher asserts that selected fields of a {{.Qualified}} have the wanted values.
+//
+// The zero Matcher matches any value.
+type Matcher struct {
+	diffs []func(got {{.Qualified}}) string
+}
+
+// Match returns a Matcher that does not select any fields yet.
+//
+//	a.That({{.Package}}.Match(){{range .Fields}}.{{.Name}}(...){{break}}{{end}}.Matches(got))
+func Match() Matcher {
+	return Matcher{}
+}
+{{range .Fields}}
+// {{.Name}} returns a Matcher that also asserts the {{.Name}} field is equal to want.
+func (m Matcher) {{.Name}}(want {{.Type}}) Matcher {
+	return m.with(func(got {{$.Qualified}}) string { return diff{{.Name}}(got.{{.Name}}, want) })
+}
+{{end}}
+// Matches asserts that the selected fields of got have the wanted values.
+func (m Matcher) Matches(got {{.Qualified}}) (bool, string) {
+	diffs := make([]string, len(m.diffs))
+	for i, diff := range m.diffs {
+		diffs[i] = diff(got)
+	}
+	return result(diffs...)
+}
+
+func (m Matcher) with(diff func(got {{.Qualified}}) string) Matcher {
+	diffs := make([]func(got {{.Qualified}}) string, len(m.diffs), len(m.diffs)+1)
+	copy(diffs, m.diffs)
+	return Matcher{diffs: append(diffs, diff)}
+}
+
+func result(diffs ...string) (bool, string) {
+	var msg strings.Builder
+	for _, diff := range diffs {
+		if diff == "" {
+			continue
+		}
+		if msg.Len() == 0 {
+			msg.WriteString("got {{.Qualified}} with ")
+		} else {
+			msg.WriteString("; ")
+		}
+		msg.WriteString(diff)
+	}
+	if msg.Len() == 0 {
+		return true, ""
+	}
+	return false, msg.String()
+}
+{{range .Fields}}
+func diff{{.Name}}(got, want {{.Type}}) string {
+	if {{.Equal}} {
+		return ""
+	}
+{{- if eq .Compare "pointer"}}
+	return fmt.Sprintf("{{.Name}} %s, not %s", formatPointer(got), formatPointer(want))
+{{- else}}
+	return fmt.Sprintf("{{.Name}} %#v, not %#v", got, want)
+{{- end}}
+}
+{{end}}
+{{- if .Helpers.pointer}}
+func equalPointers[T comparable](got, want *T) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func formatPointer[T any](p *T) string {
+	if p == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("&%#v", *p)
+}
+{{end}}
+{{- if .Helpers.slice}}
+func equalSlices[S ~[]T, T comparable](got, want S) bool {
+	if len(got) != len(want) || (got == nil) != (want == nil) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+{{end}}
+{{- if .Helpers.map}}
+func equalMaps[M ~map[K]V, K, V comparable](got, want M) bool {
+	if len(got) != len(want) || (got == nil) != (want == nil) {
+		return false
+	}
+	for k, v := range got {
+		if w, ok := want[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+{{end}}`))
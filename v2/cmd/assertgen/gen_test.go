@@ -0,0 +1,73 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestGenerateIsUpToDate(t *testing.T) {
+	// given
+	named, err := load("internal/shop", "Order")
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+
+	want, err := os.ReadFile("internal/shop/theorder/theorder_gen.go")
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+
+	// when
+	got, err := generate(named, "theorder", "assertgen -type Order")
+
+	// then
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	assert.Using(t.Errorf).That(
+		string(got) == string(want),
+		"the generated code differs from internal/shop/theorder, run go generate ./cmd/assertgen/...")
+}
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Type string
+		Err  string
+	}{
+		{Name: "Missing", Type: "Invoice", Err: "github.com/szabba/assert/v2/cmd/assertgen/internal/shop: no type Invoice"},
+		{Name: "NotStruct", Type: "Status", Err: "github.com/szabba/assert/v2/cmd/assertgen/internal/shop: Status is not a struct"},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			// when
+			_, err := load("internal/shop", tt.Type)
+
+			// then
+			assert.Using(t.Fatalf).That(err != nil, "got no error")
+			assert.Using(t.Errorf).That(theval.Equal(err.Error(), tt.Err))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package shop is an example of a package using assertgen.
+package shop
+
+import "time"
+
+//go:generate go run github.com/szabba/assert/v2/cmd/assertgen -type Order
+
+// An Order is placed by a customer.
+type Order struct {
+	ID       int
+	Customer string
+	Status   Status
+	Placed   time.Time
+	Items    []Item
+	Coupons  map[string]int
+	Shipping *Address
+	Notify   func(Status)
+
+	discount int
+}
+
+// A Status tells how far an order got.
+type Status string
+
+// The statuses an order goes through.
+const (
+	New     Status = "new"
+	Paid    Status = "paid"
+	Shipped Status = "shipped"
+)
+
+// An Item is a line of an order.
+type Item struct {
+	SKU      string
+	Quantity int
+}
+
+// An Address is where an order gets shipped to.
+type Address struct {
+	Street, City string
+}
@@ -0,0 +1,233 @@
+// Code generated by assertgen -type Order; DO NOT EDIT.
+
+// Package theorder provides reusable assertions about shop.Order values.
+package theorder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/szabba/assert/v2/cmd/assertgen/internal/shop"
+)
+
+// Equal asserts that got is equal to want.
+//
+// The fields are compared one by one and the message lists the ones that differ.
+// The fields Notify, discount are not compared.
+func Equal(got, want shop.Order) (bool, string) {
+	return result(
+		diffID(got.ID, want.ID),
+		diffCustomer(got.Customer, want.Customer),
+		diffStatus(got.Status, want.Status),
+		diffPlaced(got.Placed, want.Placed),
+		diffItems(got.Items, want.Items),
+		diffCoupons(got.Coupons, want.Coupons),
+		diffShipping(got.Shipping, want.Shipping),
+	)
+}
+
+// IDEquals asserts that the ID field of got is equal to want.
+func IDEquals(got shop.Order, want int) (bool, string) {
+	return result(diffID(got.ID, want))
+}
+
+// CustomerEquals asserts that the Customer field of got is equal to want.
+func CustomerEquals(got shop.Order, want string) (bool, string) {
+	return result(diffCustomer(got.Customer, want))
+}
+
+// StatusEquals asserts that the Status field of got is equal to want.
+func StatusEquals(got shop.Order, want shop.Status) (bool, string) {
+	return result(diffStatus(got.Status, want))
+}
+
+// PlacedEquals asserts that the Placed field of got is equal to want.
+func PlacedEquals(got shop.Order, want time.Time) (bool, string) {
+	return result(diffPlaced(got.Placed, want))
+}
+
+// ItemsEquals asserts that the Items field of got is equal to want.
+func ItemsEquals(got shop.Order, want []shop.Item) (bool, string) {
+	return result(diffItems(got.Items, want))
+}
+
+// CouponsEquals asserts that the Coupons field of got is equal to want.
+func CouponsEquals(got shop.Order, want map[string]int) (bool, string) {
+	return result(diffCoupons(got.Coupons, want))
+}
+
+// ShippingEquals asserts that the Shipping field of got is equal to want.
+func ShippingEquals(got shop.Order, want *shop.Address) (bool, string) {
+	return result(diffShipping(got.Shipping, want))
+}
+
+// A Matcher asserts that selected fields of a shop.Order have the wanted values.
+//
+// The zero Matcher matches any value.
+type Matcher struct {
+	diffs []func(got shop.Order) string
+}
+
+// Match returns a Matcher that does not select any fields yet.
+//
+//	a.That(theorder.Match().ID(...).Matches(got))
+func Match() Matcher {
+	return Matcher{}
+}
+
+// ID returns a Matcher that also asserts the ID field is equal to want.
+func (m Matcher) ID(want int) Matcher {
+	return m.with(func(got shop.Order) string { return diffID(got.ID, want) })
+}
+
+// Customer returns a Matcher that also asserts the Customer field is equal to want.
+func (m Matcher) Customer(want string) Matcher {
+	return m.with(func(got shop.Order) string { return diffCustomer(got.Customer, want) })
+}
+
+// Status returns a Matcher that also asserts the Status field is equal to want.
+func (m Matcher) Status(want shop.Status) Matcher {
+	return m.with(func(got shop.Order) string { return diffStatus(got.Status, want) })
+}
+
+// Placed returns a Matcher that also asserts the Placed field is equal to want.
+func (m Matcher) Placed(want time.Time) Matcher {
+	return m.with(func(got shop.Order) string { return diffPlaced(got.Placed, want) })
+}
+
+// Items returns a Matcher that also asserts the Items field is equal to want.
+func (m Matcher) Items(want []shop.Item) Matcher {
+	return m.with(func(got shop.Order) string { return diffItems(got.Items, want) })
+}
+
+// Coupons returns a Matcher that also asserts the Coupons field is equal to want.
+func (m Matcher) Coupons(want map[string]int) Matcher {
+	return m.with(func(got shop.Order) string { return diffCoupons(got.Coupons, want) })
+}
+
+// Shipping returns a Matcher that also asserts the Shipping field is equal to want.
+func (m Matcher) Shipping(want *shop.Address) Matcher {
+	return m.with(func(got shop.Order) string { return diffShipping(got.Shipping, want) })
+}
+
+// Matches asserts that the selected fields of got have the wanted values.
+func (m Matcher) Matches(got shop.Order) (bool, string) {
+	diffs := make([]string, len(m.diffs))
+	for i, diff := range m.diffs {
+		diffs[i] = diff(got)
+	}
+	return result(diffs...)
+}
+
+func (m Matcher) with(diff func(got shop.Order) string) Matcher {
+	diffs := make([]func(got shop.Order) string, len(m.diffs), len(m.diffs)+1)
+	copy(diffs, m.diffs)
+	return Matcher{diffs: append(diffs, diff)}
+}
+
+func result(diffs ...string) (bool, string) {
+	var msg strings.Builder
+	for _, diff := range diffs {
+		if diff == "" {
+			continue
+		}
+		if msg.Len() == 0 {
+			msg.WriteString("got shop.Order with ")
+		} else {
+			msg.WriteString("; ")
+		}
+		msg.WriteString(diff)
+	}
+	if msg.Len() == 0 {
+		return true, ""
+	}
+	return false, msg.String()
+}
+
+func diffID(got, want int) string {
+	if got == want {
+		return ""
+	}
+	return fmt.Sprintf("ID %#v, not %#v", got, want)
+}
+
+func diffCustomer(got, want string) string {
+	if got == want {
+		return ""
+	}
+	return fmt.Sprintf("Customer %#v, not %#v", got, want)
+}
+
+func diffStatus(got, want shop.Status) string {
+	if got == want {
+		return ""
+	}
+	return fmt.Sprintf("Status %#v, not %#v", got, want)
+}
+
+func diffPlaced(got, want time.Time) string {
+	if got.Equal(want) {
+		return ""
+	}
+	return fmt.Sprintf("Placed %#v, not %#v", got, want)
+}
+
+func diffItems(got, want []shop.Item) string {
+	if equalSlices(got, want) {
+		return ""
+	}
+	return fmt.Sprintf("Items %#v, not %#v", got, want)
+}
+
+func diffCoupons(got, want map[string]int) string {
+	if equalMaps(got, want) {
+		return ""
+	}
+	return fmt.Sprintf("Coupons %#v, not %#v", got, want)
+}
+
+func diffShipping(got, want *shop.Address) string {
+	if equalPointers(got, want) {
+		return ""
+	}
+	return fmt.Sprintf("Shipping %s, not %s", formatPointer(got), formatPointer(want))
+}
+
+func equalPointers[T comparable](got, want *T) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func formatPointer[T any](p *T) string {
+	if p == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("&%#v", *p)
+}
+
+func equalSlices[S ~[]T, T comparable](got, want S) bool {
+	if len(got) != len(want) || (got == nil) != (want == nil) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalMaps[M ~map[K]V, K, V comparable](got, want M) bool {
+	if len(got) != len(want) || (got == nil) != (want == nil) {
+		return false
+	}
+	for k, v := range got {
+		if w, ok := want[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
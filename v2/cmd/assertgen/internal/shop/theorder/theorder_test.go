@@ -0,0 +1,172 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package theorder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+
+	"github.com/szabba/assert/v2/cmd/assertgen/internal/shop"
+	"github.com/szabba/assert/v2/cmd/assertgen/internal/shop/theorder"
+)
+
+func order() shop.Order {
+	return shop.Order{
+		ID:       7,
+		Customer: "ann",
+		Status:   shop.Paid,
+		Placed:   time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Items:    []shop.Item{{SKU: "a", Quantity: 1}},
+		Coupons:  map[string]int{"SUMMER": 10},
+		Shipping: &shop.Address{Street: "Main St", City: "Springfield"},
+	}
+}
+
+func TestEqual(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		got, want := order(), order()
+		got.Placed = want.Placed.In(time.FixedZone("UTC+2", 2*60*60))
+		got.Notify = func(shop.Status) {}
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.Equal(got, want))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		got, want := order(), order()
+		got.ID = 8
+		got.Items = nil
+		got.Shipping = nil
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.Equal(got, want))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(
+				`got shop.Order with ID 8, not 7; ` +
+					`Items []shop.Item(nil), not []shop.Item{shop.Item{SKU:"a", Quantity:1}}; ` +
+					`Shipping nil, not &shop.Address{Street:"Main St", City:"Springfield"}`))
+	})
+
+}
+
+func TestFieldEquals(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.CouponsEquals(order(), map[string]int{"SUMMER": 10}))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.StatusEquals(order(), shop.Shipped))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`got shop.Order with Status "paid", not "shipped"`))
+	})
+
+}
+
+func TestMatcher(t *testing.T) {
+
+	t.Run("Zero", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.Match().Matches(shop.Order{}))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.Match().ID(7).Customer("ann").Matches(order()))
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+
+		// when
+		assert.Using(errFunc.Record).That(theorder.Match().ID(7).Customer("bob").Status(shop.New).Matches(order()))
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo(`got shop.Order with Customer "ann", not "bob"; Status "paid", not "new"`))
+	})
+
+	t.Run("SharedPrefix", func(t *testing.T) {
+		// given
+		var first, second assertiontesting.ErrFunc
+
+		paid := theorder.Match().Status(shop.Paid)
+		m1 := paid.ID(1)
+		m2 := paid.ID(7)
+
+		// when
+		assert.Using(first.Record).That(m1.Matches(order()))
+		assert.Using(second.Record).That(m2.Matches(order()))
+
+		// then
+		assert.Using(t.Errorf).
+			That(first.Called()).
+			That(first.MessageFormatsTo(`got shop.Order with ID 7, not 1`)).
+			That(second.NotCalled())
+	})
+
+}
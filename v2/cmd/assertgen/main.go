@@ -0,0 +1,105 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command assertgen generates reusable assertions about a struct type.
+//
+// Usage:
+//
+//	assertgen -type T [-package name] [-o dir] [package dir]
+//
+// It is meant to be run by go generate, from a comment next to the type:
+//
+//	//go:generate go run github.com/szabba/assert/v2/cmd/assertgen -type Order
+//
+// For a type Order, it writes a package called theorder to a directory of the same name.
+// The package holds:
+//
+//   - Equal, which compares two values field by field and lists the fields that differ,
+//   - an assertion like IDEquals for each field,
+//   - Match, which builds a Matcher asserting about only some of the fields.
+//
+// All of them follow the convention of the reusable assertions, so they can be passed to Asserter.That:
+//
+//	assert.Using(t.Errorf).That(theorder.Equal(got, want))
+//	assert.Using(t.Errorf).That(theorder.Match().ID(7).Status(shop.Paid).Matches(got))
+//
+// The generated code does not use reflection.
+// Fields get compared with ==, with an Equal method when the field type has one (like time.Time),
+// or element by element for pointers, slices and maps.
+// Unexported fields, and fields that cannot be compared in any of these ways, are left out.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeName := flag.String("type", "", "the name of the struct type to generate assertions about")
+	pkgName := flag.String("package", "", "the name of the generated package (default: the type name in lower case, prefixed with \"the\")")
+	out := flag.String("o", "", "the directory to write the generated package to (default: the package name)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: assertgen -type T [-package name] [-o dir] [package dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeName == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	if *pkgName == "" {
+		*pkgName = "the" + strings.ToLower(*typeName)
+	}
+	if *out == "" {
+		*out = filepath.Join(dir, *pkgName)
+	}
+
+	if err := run(dir, *typeName, *pkgName, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "assertgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, typeName, pkgName, out string) error {
+	named, err := load(dir, typeName)
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(named, pkgName, "assertgen -type "+typeName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(out, pkgName+"_gen.go"), src, 0o644)
+}
@@ -0,0 +1,12 @@
+module github.com/szabba/assert/v2/cmd/migratetestify
+
+go 1.19
+
+require (
+	github.com/szabba/assert/v2 v2.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.24.1
+)
+
+require golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1 // indirect
+
+replace github.com/szabba/assert/v2 => ../..
@@ -0,0 +1,153 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command migratetestify rewrites tests using testify to use this library.
+//
+// The command is a separate module, so that the assertions themselves do not depend on golang.org/x/tools.
+// Install it from a checkout of the repository:
+//
+//	cd v2/cmd/migratetestify && go install .
+//
+// Usage:
+//
+//	migratetestify [-w] [path ...]
+//
+// Each path is either a Go file or a directory, which is walked recursively.
+// Like gofmt, the rewritten files are printed to the standard output, unless the -w flag is given.
+//
+// Calls to the testify assert and require packages become assertions
+// using t.Errorf and t.Fatalf respectively:
+//
+//	assert.Equal(t, want, got)  // assert.Using(t.Errorf).That(theval.Equal(got, want))
+//	require.NoError(t, err)     // assert.Using(t.Fatalf).That(theerr.IsNil(err))
+//	assert.Len(t, s, 3)         // assert.Using(t.Errorf).That(theslice.Length(s, 3))
+//	assert.True(t, ok, "x")     // assert.Using(t.Errorf).That(ok, "x")
+//
+// Testify takes the expected value before the actual one, so the arguments get swapped.
+//
+// The tool only looks at the syntax of the files.
+// It does not know the types of the values being compared,
+// so the result has to be compiled to catch, for example, uses of theval.Equal with slices or maps.
+//
+// Calls that cannot be translated are left unchanged and reported on the standard error.
+// If any testify assert calls remain in a file, the package gets imported as tassert.
+// The exit status is 1 when any call could not be translated.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	write := flag.Bool("w", false, "write the result to the source files instead of the standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: migratetestify [-w] [path ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	status := 0
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if name := d.Name(); path != "." && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+
+			problems, err := migrateFile(path, *write)
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, p)
+				status = 1
+			}
+			return err
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+	os.Exit(status)
+}
+
+func migrateFile(path string, write bool) ([]problem, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out, problems, err := migrateSource(path, src)
+	if err != nil || bytes.Equal(out, src) {
+		return problems, err
+	}
+
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return problems, err
+		}
+		return problems, os.WriteFile(path, out, info.Mode())
+	}
+	_, err = os.Stdout.Write(out)
+	return problems, err
+}
+
+// migrateSource rewrites the source of a single file.
+func migrateSource(filename string, src []byte) ([]byte, []problem, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed, problems := migrate(fset, file)
+	if !changed {
+		return src, problems, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return buf.Bytes(), problems, nil
+}
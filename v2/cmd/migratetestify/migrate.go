@@ -0,0 +1,404 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+const (
+	testifyAssertPath  = "github.com/stretchr/testify/assert"
+	testifyRequirePath = "github.com/stretchr/testify/require"
+
+	assertPath   = "github.com/szabba/assert/v2"
+	thevalPath   = "github.com/szabba/assert/v2/assertions/theval"
+	theerrPath   = "github.com/szabba/assert/v2/assertions/theerr"
+	theslicePath = "github.com/szabba/assert/v2/assertions/theslice"
+
+	// renamedAssert is the name the testify assert package gets imported under
+	// when some of its calls could not be translated.
+	renamedAssert = "tassert"
+)
+
+// A problem describes a call that could not be translated.
+type problem struct {
+	Pos    token.Position
+	Call   string
+	Reason string
+}
+
+func (p problem) String() string {
+	return fmt.Sprintf("%s: cannot translate %s: %s", p.Pos, p.Call, p.Reason)
+}
+
+// A rule translates the arguments of a testify function, without the leading testing.T, into the arguments of Asserter.That.
+type rule struct {
+	// params is the number of arguments the function takes before the optional message.
+	params int
+	// translate builds the arguments of Asserter.That out of the params.
+	translate func(m *migration, args []ast.Expr) ([]ast.Expr, error)
+	// message is the default message for rules that do not use a reusable assertion.
+	// Rules without one cannot keep a custom message.
+	message string
+}
+
+var rules = map[string]rule{
+	// Testify takes the expected value first, while the reusable assertions take what was got first.
+	"Equal":    {params: 2, translate: func(m *migration, args []ast.Expr) ([]ast.Expr, error) { return m.equal("Equal", args[1], args[0]) }},
+	"NotEqual": {params: 2, translate: func(m *migration, args []ast.Expr) ([]ast.Expr, error) { return m.equal("NotEqual", args[1], args[0]) }},
+	"Less":     {params: 2, translate: reusable(thevalPath, "LessThan", 0, 1)},
+	"Greater":  {params: 2, translate: reusable(thevalPath, "LessThan", 1, 0)},
+	"Zero":     {params: 1, translate: reusable(thevalPath, "Zero", 0)},
+	"NotZero":  {params: 1, translate: reusable(thevalPath, "NotZero", 0)},
+
+	"NoError": {params: 1, translate: reusable(theerrPath, "IsNil", 0)},
+	"ErrorIs": {params: 2, translate: reusable(theerrPath, "Is", 0, 1)},
+
+	"Len":      {params: 2, translate: reusable(theslicePath, "Length", 0, 1)},
+	"Empty":    {params: 1, translate: reusable(theslicePath, "Empty", 0)},
+	"NotEmpty": {params: 1, translate: reusable(theslicePath, "NotEmpty", 0)},
+
+	"True":  {params: 1, translate: condition(func(m *migration, x ast.Expr) ast.Expr { return x }), message: "got false"},
+	"False": {params: 1, translate: condition((*migration).not), message: "got true"},
+	"Error": {params: 1, translate: condition((*migration).notNil), message: "got no error"},
+}
+
+// reusable translates a call into a call of a reusable assertion, taking the params in the given order.
+func reusable(path, name string, order ...int) func(m *migration, args []ast.Expr) ([]ast.Expr, error) {
+	return func(m *migration, args []ast.Expr) ([]ast.Expr, error) {
+		reordered := make([]ast.Expr, len(order))
+		for i, j := range order {
+			reordered[i] = args[j]
+			if i != j {
+				m.reposition(args[j])
+			}
+		}
+		return []ast.Expr{m.call(path, name, reordered...)}, nil
+	}
+}
+
+func condition(cond func(m *migration, x ast.Expr) ast.Expr) func(m *migration, args []ast.Expr) ([]ast.Expr, error) {
+	return func(m *migration, args []ast.Expr) ([]ast.Expr, error) {
+		return []ast.Expr{cond(m, args[0])}, nil
+	}
+}
+
+func (m *migration) not(x ast.Expr) ast.Expr {
+	return &ast.UnaryExpr{OpPos: m.pos, Op: token.NOT, X: m.parenthesize(x)}
+}
+
+func (m *migration) notNil(x ast.Expr) ast.Expr {
+	if _, ok := x.(*ast.BinaryExpr); ok {
+		x = m.parenthesize(x)
+	}
+	return &ast.BinaryExpr{X: x, OpPos: m.pos, Op: token.NEQ, Y: m.ident("nil")}
+}
+
+func (m *migration) parenthesize(x ast.Expr) ast.Expr {
+	switch x.(type) {
+	case *ast.Ident, *ast.BasicLit, *ast.CallExpr, *ast.SelectorExpr, *ast.IndexExpr, *ast.ParenExpr, *ast.UnaryExpr:
+		return x
+	}
+	return &ast.ParenExpr{Lparen: m.pos, X: x, Rparen: m.pos}
+}
+
+// A migration rewrites a single file.
+type migration struct {
+	fset     *token.FileSet
+	file     *ast.File
+	imports  map[string]bool
+	problems []problem
+
+	// pos is the position of the call being translated.
+	// The nodes created for it are all placed there,
+	// so that the printer keeps the comments around it in place and does not break lines.
+	pos token.Pos
+	// created holds the identifiers created by translations.
+	created map[*ast.Ident]bool
+}
+
+// migrate rewrites the testify calls in file in place.
+//
+// It reports whether the file changed and the calls it could not translate.
+func migrate(fset *token.FileSet, file *ast.File) (bool, []problem) {
+	m := &migration{fset: fset, file: file, imports: map[string]bool{}, created: map[*ast.Ident]bool{}}
+
+	testify := map[string]string{} // local name -> ErrorFunc method
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		switch path {
+		case testifyAssertPath:
+			testify[localName(spec, "assert")] = "Errorf"
+		case testifyRequirePath:
+			testify[localName(spec, "require")] = "Fatalf"
+		}
+	}
+	delete(testify, "_")
+	delete(testify, ".")
+	if len(testify) == 0 {
+		return false, nil
+	}
+
+	changed := false
+	astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
+		call, ok := c.Node().(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		pkg, fn, ok := testifyFunc(call, testify)
+		if !ok {
+			return true
+		}
+		if replacement, ok := m.translate(call, pkg, fn, testify[pkg]); ok {
+			c.Replace(replacement)
+			changed = true
+		}
+		return true
+	})
+	if !changed {
+		return false, m.problems
+	}
+
+	m.fixImports(testify)
+	return true, m.problems
+}
+
+func localName(spec *ast.ImportSpec, name string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	return name
+}
+
+// testifyFunc tells whether call is a call of a function from one of the testify packages.
+func testifyFunc(call *ast.CallExpr, testify map[string]string) (pkg, fn string, ok bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	if !ok || id.Obj != nil {
+		// A package name is never resolved to a local object by the parser.
+		return "", "", false
+	}
+	if _, ok := testify[id.Name]; !ok {
+		return "", "", false
+	}
+	return id.Name, sel.Sel.Name, true
+}
+
+func (m *migration) translate(call *ast.CallExpr, pkg, fn, errorFunc string) (ast.Expr, bool) {
+	m.pos = call.Pos()
+	fail := func(reason string, args ...any) (ast.Expr, bool) {
+		m.problems = append(m.problems, problem{
+			Pos:    m.fset.Position(call.Pos()),
+			Call:   pkg + "." + fn,
+			Reason: fmt.Sprintf(reason, args...),
+		})
+		return nil, false
+	}
+
+	r, formatted := rules[fn], false
+	if r.translate == nil && strings.HasSuffix(fn, "f") {
+		r, formatted = rules[strings.TrimSuffix(fn, "f")], true
+	}
+	if r.translate == nil {
+		return fail("there is no equivalent of %s", fn)
+	}
+	if call.Ellipsis.IsValid() {
+		return fail("the arguments are spread from a slice")
+	}
+	if len(call.Args) < 1+r.params {
+		return fail("it needs %d arguments, but has %d", 1+r.params, len(call.Args))
+	}
+
+	t, args, msgAndArgs := call.Args[0], call.Args[1:1+r.params], call.Args[1+r.params:]
+	if formatted && len(msgAndArgs) == 0 {
+		return fail("it has no format string")
+	}
+
+	thatArgs, err := r.translate(m, args)
+	if err != nil {
+		return fail("%s", err)
+	}
+
+	switch {
+	case len(msgAndArgs) == 0 && r.message != "":
+		thatArgs = append(thatArgs, &ast.BasicLit{ValuePos: m.pos, Kind: token.STRING, Value: strconv.Quote(r.message)})
+	case len(msgAndArgs) > 0 && r.message == "":
+		return fail("a custom message cannot be added to a reusable assertion")
+	case len(msgAndArgs) > 0 && !isStringLit(msgAndArgs[0]):
+		return fail("the message is not a string literal")
+	default:
+		thatArgs = append(thatArgs, msgAndArgs...)
+	}
+
+	m.imports[assertPath] = true
+	using := m.newCall(m.selector(m.ident("assert"), "Using"),
+		m.selector(m.parenthesize(t), errorFunc))
+	that := m.newCall(m.selector(using, "That"), thatArgs...)
+	that.Rparen = call.Rparen
+	return that, true
+}
+
+func isStringLit(x ast.Expr) bool {
+	lit, ok := x.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+// equal translates Equal and NotEqual.
+//
+// Testify compares values deeply, while theval.Equal only accepts comparable types.
+// Slice literals are compared with theslice instead, but other uses of uncomparable types are left for the compiler to catch.
+func (m *migration) equal(name string, got, want ast.Expr) ([]ast.Expr, error) {
+	path := thevalPath
+	for _, x := range []ast.Expr{got, want} {
+		lit, ok := x.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		switch typ := lit.Type.(type) {
+		case *ast.ArrayType:
+			if typ.Len == nil {
+				path = theslicePath
+			}
+		case *ast.MapType:
+			return nil, fmt.Errorf("maps cannot be compared")
+		}
+	}
+	m.reposition(got)
+	m.reposition(want)
+	return []ast.Expr{m.call(path, name, got, want)}, nil
+}
+
+// reposition moves all of x to the position of the call being translated.
+//
+// Arguments that got swapped would otherwise keep their original lines and be printed with odd line breaks.
+func (m *migration) reposition(x ast.Expr) {
+	posType := reflect.TypeOf(token.NoPos)
+	ast.Inspect(x, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		v := reflect.ValueOf(n).Elem()
+		if v.Kind() != reflect.Struct {
+			return true
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.Type() == posType && token.Pos(f.Int()).IsValid() {
+				f.SetInt(int64(m.pos))
+			}
+		}
+		return true
+	})
+}
+
+func (m *migration) call(path, name string, args ...ast.Expr) ast.Expr {
+	m.imports[path] = true
+	pkg := path[strings.LastIndex(path, "/")+1:]
+	return m.newCall(m.selector(m.ident(pkg), name), args...)
+}
+
+func (m *migration) newCall(fun ast.Expr, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{Fun: fun, Lparen: m.pos, Args: args, Rparen: m.pos}
+}
+
+func (m *migration) selector(x ast.Expr, name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{X: x, Sel: m.ident(name)}
+}
+
+func (m *migration) ident(name string) *ast.Ident {
+	id := &ast.Ident{NamePos: m.pos, Name: name}
+	m.created[id] = true
+	return id
+}
+
+// fixImports adds the imports the translations need and removes the testify imports that are no longer used.
+//
+// When some calls to the testify assert package remain, it gets renamed, so it does not clash with this library.
+func (m *migration) fixImports(testify map[string]string) {
+	// The new imports are added first, so they get grouped with the testify ones.
+	for _, path := range []string{assertPath, thevalPath, theerrPath, theslicePath} {
+		if m.imports[path] {
+			astutil.AddImport(m.fset, m.file, path)
+		}
+	}
+
+	// Deleting an import modifies m.file.Imports.
+	specs := append([]*ast.ImportSpec(nil), m.file.Imports...)
+	for _, spec := range specs {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if path != testifyAssertPath && path != testifyRequirePath {
+			continue
+		}
+
+		name := localName(spec, strings.TrimPrefix(path, "github.com/stretchr/testify/"))
+		uses := m.uses(name)
+		switch {
+		case len(uses) == 0:
+			astutil.DeleteNamedImport(m.fset, m.file, importName(spec), path)
+		case name == "assert":
+			for _, id := range uses {
+				id.Name = renamedAssert
+			}
+			spec.Name = &ast.Ident{NamePos: spec.Path.Pos(), Name: renamedAssert}
+		}
+	}
+
+	for _, decl := range m.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if ok && gen.Tok == token.IMPORT && len(gen.Specs) == 1 {
+			gen.Lparen, gen.Rparen = token.NoPos, token.NoPos
+		}
+	}
+}
+
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name == nil {
+		return ""
+	}
+	return spec.Name.Name
+}
+
+// uses finds the references to the package imported under name that are left in the file.
+func (m *migration) uses(name string) []*ast.Ident {
+	var uses []*ast.Ident
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && id.Name == name && id.Obj == nil && !m.created[id] {
+			uses = append(uses, id)
+		}
+		return true
+	})
+	return uses
+}
@@ -0,0 +1,345 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestMigrateSource(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Src      string
+		Want     string
+		Problems []string
+	}{
+		{
+			Name: "AssertAndRequire",
+			Src: `package x
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestX(t *testing.T) {
+	got, err := f()
+	require.NoError(t, err)
+	// want, got
+	assert.Equal(t, 3, got)
+	assert.Len(t, s(), 1)
+}
+`,
+			Want: `package x
+
+import (
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestX(t *testing.T) {
+	got, err := f()
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	// want, got
+	assert.Using(t.Errorf).That(theval.Equal(got, 3))
+	assert.Using(t.Errorf).That(theslice.Length(s(), 1))
+}
+`,
+		},
+		{
+			Name: "ArgumentOrder",
+			Src: `package x
+
+import "github.com/stretchr/testify/assert"
+
+func TestX(t *testing.T) {
+	assert.NotEqual(t, "a", got())
+	assert.Greater(t, got(), 1)
+	assert.Less(t, got(), 9)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, []int{1, 2}, got())
+}
+`,
+			Want: `package x
+
+import (
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theerr"
+	"github.com/szabba/assert/v2/assertions/theslice"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestX(t *testing.T) {
+	assert.Using(t.Errorf).That(theval.NotEqual(got(), "a"))
+	assert.Using(t.Errorf).That(theval.LessThan(1, got()))
+	assert.Using(t.Errorf).That(theval.LessThan(got(), 9))
+	assert.Using(t.Errorf).That(theerr.Is(err, io.EOF))
+	assert.Using(t.Errorf).That(theslice.Equal(got(), []int{1, 2}))
+}
+`,
+		},
+		{
+			Name: "MultilineCall",
+			Src: `package x
+
+import "github.com/stretchr/testify/assert"
+
+func TestX(t *testing.T) {
+	assert.Equal(t, []string{
+		"a",
+		"b",
+	}, got)
+}
+`,
+			Want: `package x
+
+import (
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theslice"
+)
+
+func TestX(t *testing.T) {
+	assert.Using(t.Errorf).That(theslice.Equal(got, []string{"a", "b"}))
+}
+`,
+		},
+		{
+			Name: "Conditions",
+			Src: `package x
+
+import "github.com/stretchr/testify/require"
+
+func TestX(t *testing.T) {
+	require.True(t, ok)
+	require.False(t, a == b, "a is %v", a)
+	require.Errorf(t, err, "for %q", in)
+}
+`,
+			Want: `package x
+
+import "github.com/szabba/assert/v2"
+
+func TestX(t *testing.T) {
+	assert.Using(t.Fatalf).That(ok, "got false")
+	assert.Using(t.Fatalf).That(!(a == b), "a is %v", a)
+	assert.Using(t.Fatalf).That(err != nil, "for %q", in)
+}
+`,
+		},
+		{
+			Name: "Untranslatable",
+			Src: `package x
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestX(t *testing.T) {
+	assert.Contains(t, s, "a")
+	assert.Equal(t, 1, got(), "message")
+	assert.Equal(t, map[string]int{}, got())
+	require.True(t, ok, msg)
+	require.NoError(t)
+	assert.Zero(t, got())
+}
+`,
+			Want: `package x
+
+import (
+	tassert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestX(t *testing.T) {
+	tassert.Contains(t, s, "a")
+	tassert.Equal(t, 1, got(), "message")
+	tassert.Equal(t, map[string]int{}, got())
+	require.True(t, ok, msg)
+	require.NoError(t)
+	assert.Using(t.Errorf).That(theval.Zero(got()))
+}
+`,
+			Problems: []string{
+				"x_test.go:9:2: cannot translate assert.Contains: there is no equivalent of Contains",
+				"x_test.go:10:2: cannot translate assert.Equal: a custom message cannot be added to a reusable assertion",
+				"x_test.go:11:2: cannot translate assert.Equal: maps cannot be compared",
+				"x_test.go:12:2: cannot translate require.True: the message is not a string literal",
+				"x_test.go:13:2: cannot translate require.NoError: it needs 2 arguments, but has 1",
+			},
+		},
+		{
+			Name: "NamedImport",
+			Src: `package x
+
+import is "github.com/stretchr/testify/assert"
+
+func TestX(t *testing.T) {
+	is.Empty(t, got())
+	is.Contains(t, got(), 1)
+}
+`,
+			Want: `package x
+
+import (
+	is "github.com/stretchr/testify/assert"
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theslice"
+)
+
+func TestX(t *testing.T) {
+	assert.Using(t.Errorf).That(theslice.Empty(got()))
+	is.Contains(t, got(), 1)
+}
+`,
+			Problems: []string{
+				"x_test.go:7:2: cannot translate is.Contains: there is no equivalent of Contains",
+			},
+		},
+		{
+			Name: "ShadowedName",
+			Src: `package x
+
+import "github.com/stretchr/testify/assert"
+
+func TestX(t *testing.T) {
+	assert.True(t, true)
+	{
+		assert := assert.New(t)
+		assert.True(ok)
+	}
+}
+`,
+			Want: `package x
+
+import (
+	tassert "github.com/stretchr/testify/assert"
+	"github.com/szabba/assert/v2"
+)
+
+func TestX(t *testing.T) {
+	assert.Using(t.Errorf).That(true, "got false")
+	{
+		assert := tassert.New(t)
+		assert.True(ok)
+	}
+}
+`,
+			Problems: []string{
+				"x_test.go:8:13: cannot translate assert.New: there is no equivalent of New",
+			},
+		},
+		{
+			Name: "NoTestify",
+			Src: `package x
+
+import "github.com/szabba/assert/v2"
+
+func f() { assert.UsingPanic().That(true, "") }
+`,
+			Want: `package x
+
+import "github.com/szabba/assert/v2"
+
+func f() { assert.UsingPanic().That(true, "") }
+`,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			// given
+			src := []byte(tt.Src)
+
+			// when
+			out, problems, err := migrateSource("x_test.go", src)
+
+			// then
+			msgs := make([]string, len(problems))
+			for i, p := range problems {
+				msgs[i] = p.String()
+			}
+			if tt.Problems == nil {
+				tt.Problems = []string{}
+			}
+
+			assert.Using(t.Fatalf).That(theerr.IsNil(err))
+			assert.Using(t.Errorf).
+				That(theval.Equal(string(out), tt.Want)).
+				That(theslice.Equal(msgs, tt.Problems))
+		})
+	}
+}
+
+func TestMigrateSourceInvalid(t *testing.T) {
+	// given
+	src := []byte("package x\n\nfunc {")
+
+	// when
+	_, _, err := migrateSource("x_test.go", src)
+
+	// then
+	assert.Using(t.Errorf).That(err != nil, "got no error")
+	if err != nil {
+		assert.Using(t.Errorf).That(strings.HasPrefix(err.Error(), "x_test.go:3:6:"), "got error %q", err)
+	}
+}
+
+func TestMigrateFileKeepsFileMode(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "x_test.go")
+	src := "package x\n\nimport \"github.com/stretchr/testify/assert\"\n\nfunc TestX(t *testing.T) { assert.True(t, ok()) }\n"
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	_, err := migrateFile(path, true)
+
+	// then
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	info, err := os.Stat(path)
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	assert.Using(t.Errorf).That(theval.Equal(info.Mode().Perm(), 0o600))
+
+	out, err := os.ReadFile(path)
+	assert.Using(t.Fatalf).That(theerr.IsNil(err))
+	assert.Using(t.Errorf).That(strings.Contains(string(out), "assert.Using(t.Errorf)"), "file was not rewritten:\n%s", out)
+}
@@ -0,0 +1,166 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package contract checks preconditions, postconditions and type invariants.
+//
+// The contract of a function is stated at its start:
+//
+//	func (acc *Account) Withdraw(amount int) (balance int) {
+//	    contract.Requires(amount > 0, "amount %d is not positive", amount)
+//	    defer contract.Maintains(acc)()
+//	    defer contract.Ensures(func() (bool, string) { return theval.LessThan(-1, balance) })
+//	    ...
+//	}
+//
+// Violations are reported through an Asserter.
+// The package level functions panic, like assert.UsingPanic.
+// To report violations differently, create a Checker using Using.
+//
+// The failure messages name the kind of contract violated and the function it belongs to.
+package contract
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/szabba/assert/v2"
+)
+
+// Invariant is implemented by types with invariants.
+//
+// Invariant returns false and a description when the invariant does not hold,
+// just like a reusable assertion.
+type Invariant interface {
+	Invariant() (bool, string)
+}
+
+// A Checker checks contracts and reports violations using an Asserter.
+type Checker struct {
+	a assert.Asserter
+}
+
+// Using creates a Checker that reports contract violations using a.
+func Using(a assert.Asserter) Checker {
+	return Checker{a}
+}
+
+var panicking = Using(assert.UsingPanic())
+
+// Requires asserts a precondition of the calling function.
+//
+// It panics when the precondition does not hold.
+func Requires(cond bool, msgFmt string, args ...any) {
+	panicking.requires(cond, msgFmt, args)
+}
+
+// Ensures asserts a postcondition of the calling function.
+//
+// It is meant to be deferred, so that check runs when the function returns and can see its named results.
+// It panics when the postcondition does not hold.
+func Ensures(check func() (bool, string)) {
+	panicking.ensures(check)
+}
+
+// Maintains asserts that the invariant of v holds when the calling function starts,
+// and returns a function that asserts it again.
+//
+// The returned function is meant to be deferred, so that the invariant is checked again when the calling function returns:
+//
+//	defer contract.Maintains(v)()
+//
+// It panics when the invariant does not hold.
+func Maintains(v Invariant) func() {
+	return panicking.maintains(v)
+}
+
+// Requires asserts a precondition of the calling function.
+func (c Checker) Requires(cond bool, msgFmt string, args ...any) {
+	c.requires(cond, msgFmt, args)
+}
+
+// Ensures asserts a postcondition of the calling function.
+//
+// It is meant to be deferred, see the package level Ensures.
+func (c Checker) Ensures(check func() (bool, string)) {
+	c.ensures(check)
+}
+
+// Maintains asserts the invariant of v on entry to and exit from the calling function.
+//
+// See the package level Maintains.
+func (c Checker) Maintains(v Invariant) func() {
+	return c.maintains(v)
+}
+
+// The unexported methods are called through exactly one exported function or method,
+// so that they all find the function the contract belongs to at the same depth.
+
+func (c Checker) requires(cond bool, msgFmt string, args []any) {
+	if cond {
+		return
+	}
+	c.a.That(false, "precondition of %s violated: %s", caller(), fmt.Sprintf(msgFmt, args...))
+}
+
+func (c Checker) ensures(check func() (bool, string)) {
+	if ok, msg := check(); !ok {
+		c.a.That(false, "postcondition of %s violated: %s", caller(), msg)
+	}
+}
+
+func (c Checker) maintains(v Invariant) func() {
+	fn := caller()
+	if ok, msg := v.Invariant(); !ok {
+		c.a.That(false, "invariant of %T violated on entry to %s: %s", v, fn, msg)
+	}
+	return func() {
+		if ok, msg := v.Invariant(); !ok {
+			c.a.That(false, "invariant of %T violated on exit from %s: %s", v, fn, msg)
+		}
+	}
+}
+
+// caller returns the name of the function whose contract is being checked.
+func caller() string {
+	// Skip runtime.Callers, caller, the unexported method and the exported function or method.
+	pcs := make([]uintptr, 8)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs)])
+	for {
+		frame, more := frames.Next()
+		// Deferred calls can be made by the runtime rather than the function itself.
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			return shortName(frame.Function)
+		}
+		if !more {
+			return "unknown function"
+		}
+	}
+}
+
+// shortName strips the import path from a function name, leaving the package name.
+func shortName(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		return function[i+1:]
+	}
+	return function
+}
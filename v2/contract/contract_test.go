@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package contract_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/assertiontesting"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/contract"
+)
+
+type account struct {
+	balance int
+}
+
+func (acc *account) Invariant() (bool, string) {
+	return acc.balance >= 0, fmt.Sprintf("negative balance %d", acc.balance)
+}
+
+func (acc *account) withdraw(c contract.Checker, amount int) (balance int) {
+	c.Requires(amount > 0, "amount %d is not positive", amount)
+	defer c.Maintains(acc)()
+	defer c.Ensures(func() (bool, string) { return theval.Equal(balance, acc.balance) })
+
+	acc.balance -= amount
+	if amount == 13 {
+		return -13
+	}
+	return acc.balance
+}
+
+func TestRequires(t *testing.T) {
+
+	t.Run("True", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		acc := &account{balance: 10}
+
+		// when
+		acc.withdraw(contract.Using(assert.Using(errFunc.Record)), 5)
+
+		// then
+		assert.Using(t.Errorf).That(errFunc.NotCalled())
+	})
+
+	t.Run("False", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		acc := &account{balance: 10}
+
+		// when
+		acc.withdraw(contract.Using(assert.Using(errFunc.Record)), 0)
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo("precondition of contract_test.(*account).withdraw violated: amount 0 is not positive"))
+	})
+
+}
+
+func TestEnsures(t *testing.T) {
+	// given
+	var errFunc assertiontesting.ErrFunc
+	acc := &account{balance: 20}
+
+	// when
+	acc.withdraw(contract.Using(assert.Using(errFunc.Record)), 13)
+
+	// then
+	assert.Using(t.Errorf).
+		That(errFunc.Called()).
+		That(errFunc.MessageFormatsTo("postcondition of contract_test.(*account).withdraw violated: got -13, not 7"))
+}
+
+func TestMaintains(t *testing.T) {
+
+	t.Run("OnEntry", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		acc := &account{balance: -1}
+
+		// when
+		contract.Using(assert.Using(errFunc.Record)).Maintains(acc)
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo("invariant of *contract_test.account violated on entry to contract_test.TestMaintains.func1: negative balance -1"))
+	})
+
+	t.Run("OnExit", func(t *testing.T) {
+		// given
+		var errFunc assertiontesting.ErrFunc
+		acc := &account{balance: 10}
+
+		// when
+		acc.withdraw(contract.Using(assert.Using(errFunc.Record)), 11)
+
+		// then
+		assert.Using(t.Errorf).
+			That(errFunc.Called()).
+			That(errFunc.MessageFormatsTo("invariant of *contract_test.account violated on exit from contract_test.(*account).withdraw: negative balance -1"))
+	})
+
+}
+
+func TestPackageLevelContractsPanic(t *testing.T) {
+	// given
+	defer func() {
+		// then
+		err, _ := recover().(*assert.AssertionError)
+		assert.Using(t.Fatalf).That(err != nil, "got no *assert.AssertionError panic")
+		assert.Using(t.Errorf).That(theval.Equal(err.Message, "precondition of contract_test.TestPackageLevelContractsPanic violated: nope"))
+	}()
+
+	// when
+	contract.Requires(false, "nope")
+}
@@ -47,6 +47,8 @@ To do that call Using with an ErrorFunc.
 
 An ErrorFunc specifies what the reaction to failure should be.
 When Using is passed a nil ErrorFunc, it behaves the same as UsingPanic.
+Asserters created with UsingPanic panic with an *AssertionError,
+which holds the failure message and the stack of the failed assertion.
 
 You can use many functions and methods in the standard library as ErrorFuncs.
 For example:
@@ -57,6 +59,75 @@ For example:
   - log.Panicf
   - log.Fatalf
 
+With Go 1.21 or later, Slog and SlogPanic create ErrorFuncs that log failures using a log/slog.Logger.
+
+# Middleware
+
+Use wraps the ErrorFunc of an Asserter in middleware, which can change or intercept failures:
+
+	a := assert.Using(t.Errorf).Use(assert.WithStack)
+
+Hook adds middleware to all asserters, which is useful for counting failures or annotating them in CI:
+
+	t.Cleanup(assert.Hook(countFailures))
+
+# CI annotations
+
+GitHubActions creates an ErrorFunc writing failures as GitHub Actions annotations,
+so they show up inline on pull request diffs.
+JUnitReport writes failures to a JUnit XML file.
+Combined with Also and Hook, they can report the failures of a whole test binary:
+
+	func TestMain(m *testing.M) {
+	    assert.Hook(assert.Also(assert.GitHubActions(os.Stdout)))
+	    os.Exit(m.Run())
+	}
+
+# Expensive messages
+
+The arguments to That are evaluated even when the assertion passes.
+When a message is expensive to build, wrap it in a Lazy, so it only gets computed on failure:
+
+	assert.UsingPanic().That(tree.Balanced(), "tree is not balanced:\n%s", assert.Lazy(tree.Dump))
+
+Lazy and other format arguments still allocate when the assertion passes, since they escape to the heap.
+Passing assertions only avoid allocations when they use a plain message, a reusable assertion, or ThatFunc.
+On hot paths, build the message inside a check passed to ThatFunc:
+
+	a.ThatFunc(func() (bool, string) {
+	    if tree.Balanced() {
+	        return true, ""
+	    }
+	    return false, "tree is not balanced:\n" + tree.Dump()
+	})
+
+# Invariants
+
+Invariant asserts something that should always hold in production code:
+
+	assert.Invariant(len(q.items) <= q.capacity, "queue holds %d items over capacity %d", len(q.items), q.capacity)
+
+Invariants are only checked in programs built with the assert_debug build tag.
+Failed invariants panic, unless SetInvariantMode switches them to being logged and counted.
+
+# Metrics
+
+To know how often assertions left in production code fail, count them using Metrics:
+
+	metrics := assert.NewMetrics()
+	expvar.Publish("assertions", metrics)
+
+	a := assert.Using(log.Printf).WithMetrics(metrics)
+
+# Table-driven tests
+
+The Table function of the [asserttest] package runs a function for each case of a table in a separate subtest.
+The function receives an Asserter that reports failures of its case:
+
+	asserttest.Table(t, cases, func(a assert.Asserter, c Case) {
+	    a.That(theval.Equal(Sum(c.In...), c.Want))
+	})
+
 # Reusable assertions
 
 We provide some pre-made reusable [assertions], so you can call
@@ -105,6 +176,7 @@ You don't have to write the function in this example though.
 Just use [theerr.IsNil].
 
 [assertions]: https://pkg.go.dev/github.com/szabba/assert/v2/assertions
+[asserttest]: https://pkg.go.dev/github.com/szabba/assert/v2/asserttest
 [Calls]: https://go.dev/ref/spec#Calls
 [theerr.IsNil]: https://pkg.go.dev/github.com/szabba/assert/v2/assertions/theerr#IsNil
 */
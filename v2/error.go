@@ -0,0 +1,129 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/szabba/assert/v2/internal/caller"
+)
+
+// An AssertionError is what asserters created with UsingPanic panic with when an assertion fails.
+//
+// Code recovering from panics can tell failed assertions apart from other panics with errors.As:
+//
+//	if err, ok := recover().(error); ok {
+//	    var failure *assert.AssertionError
+//	    if errors.As(err, &failure) {
+//	        log.Printf("assertion failed at %s:%d: %s", failure.Stack[0].File, failure.Stack[0].Line, failure.Message)
+//	    }
+//	}
+type AssertionError struct {
+	// Message is the formatted failure message.
+	Message string
+
+	// Format and Args are the message format and arguments passed to the assertion.
+	Format string
+	Args   []any
+
+	// Got and Want are the values compared by the assertion, when it passed them as a Comparison argument.
+	// Reusable assertions, like the ones in the assertions packages, only return a message,
+	// so for failures they report Got and Want are nil.
+	Got, Want any
+
+	// Stack holds the stack frames from the one where the assertion was made outwards.
+	Stack []runtime.Frame
+}
+
+// newAssertionError creates an AssertionError for a failure that is being reported.
+func newAssertionError(msg, msgFmt string, args []any) *AssertionError {
+	err := &AssertionError{
+		Message: msg,
+		Format:  msgFmt,
+		Args:    args,
+		Stack:   caller.Frames(),
+	}
+	for _, arg := range args {
+		if c, ok := arg.(Comparison); ok {
+			err.Got, err.Want = c.Got, c.Want
+			break
+		}
+	}
+	return err
+}
+
+// Error returns the failure message.
+func (err *AssertionError) Error() string {
+	return err.Message
+}
+
+// Unwrap returns the first argument of the assertion that is an error, if any.
+//
+// That lets errors.Is and errors.As see errors the assertion reported about:
+//
+//	a.That(err == nil, "unexpected error: %v", err)
+func (err *AssertionError) Unwrap() error {
+	for _, arg := range err.Args {
+		if e, ok := arg.(error); ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// StackTrace formats the stack of the failed assertion like the stack traces of panics.
+func (err *AssertionError) StackTrace() string {
+	var b strings.Builder
+	for i, frame := range err.Stack {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return b.String()
+}
+
+// A Comparison holds the values an assertion compared.
+//
+// Passed as an argument to That, it formats as "got <got>, not <want>",
+// and it makes the values available in the AssertionError when the asserter panics:
+//
+//	a.That(got == want, "%v", assert.Comparison{Got: got, Want: want})
+//
+// Reusable assertions cannot pass a Comparison, since they return the message as a string.
+// Call That with a Comparison instead of using a reusable assertion when the values are needed.
+type Comparison struct {
+	Got, Want any
+}
+
+// String formats the values using the %#v verb.
+func (c Comparison) String() string {
+	return fmt.Sprintf("got %#v, not %#v", c.Got, c.Want)
+}
+
+// panicking is the ErrorFunc of asserters created with UsingPanic.
+func panicking(msgFmt string, args ...any) {
+	panic(newAssertionError(fmt.Sprintf(msgFmt, args...), msgFmt, args))
+}
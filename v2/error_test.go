@@ -0,0 +1,146 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestAssertionErrorCanBeFoundWithErrorsAs(t *testing.T) {
+	// given
+	p := catchPanic(func() { assert.UsingPanic().That(false, "Oops") })
+	err, ok := p.(error)
+	if !ok {
+		t.Fatalf("got panic %#v, not an error", p)
+	}
+	wrapped := fmt.Errorf("request failed: %w", err)
+
+	// when
+	var failure *assert.AssertionError
+	found := errors.As(wrapped, &failure)
+
+	// then
+	if !found {
+		t.Fatalf("errors.As did not find an *assert.AssertionError in %#v", wrapped)
+	}
+	if failure.Message != "Oops" {
+		t.Errorf("got message %q, not %q", failure.Message, "Oops")
+	}
+}
+
+func TestAssertionErrorHoldsTheStackOfTheAssertion(t *testing.T) {
+	// given
+	a := assert.UsingPanic()
+
+	// when
+	_, file, line, _ := runtime.Caller(0)
+	p := catchPanic(func() { a.That(false, "Oops") })
+
+	// then
+	err, _ := p.(*assert.AssertionError)
+	if err == nil || len(err.Stack) == 0 {
+		t.Fatalf("got panic %#v, not an *assert.AssertionError with a stack", p)
+	}
+	if err.Stack[0].File != file || err.Stack[0].Line != line+1 {
+		t.Errorf("got stack starting at %s:%d, not %s:%d", err.Stack[0].File, err.Stack[0].Line, file, line+1)
+	}
+	wantTrace := fmt.Sprintf("github.com/szabba/assert/v2_test.TestAssertionErrorHoldsTheStackOfTheAssertion.func1\n\t%s:%d\n", file, line+1)
+	if trace := err.StackTrace(); !strings.HasPrefix(trace, wantTrace) {
+		t.Errorf("got stack trace %q, want it to start with %q", trace, wantTrace)
+	}
+}
+
+func TestAssertionErrorHoldsTheFormatAndArgs(t *testing.T) {
+	// given
+	// when
+	p := catchPanic(func() { assert.UsingPanic().That(false, "got %d, want %d", 1, 2) })
+
+	// then
+	err, _ := p.(*assert.AssertionError)
+	if err == nil {
+		t.Fatalf("got panic %#v, not an *assert.AssertionError", p)
+	}
+	if err.Format != "got %d, want %d" || fmt.Sprint(err.Args) != "[1 2]" {
+		t.Errorf("got format %q and args %v, not %q and [1 2]", err.Format, err.Args, "got %d, want %d")
+	}
+}
+
+func TestAssertionErrorHoldsComparedValues(t *testing.T) {
+	// given
+	// when
+	p := catchPanic(func() {
+		assert.UsingPanic().That(false, "balance: %v", assert.Comparison{Got: 10, Want: 20})
+	})
+
+	// then
+	err, _ := p.(*assert.AssertionError)
+	if err == nil {
+		t.Fatalf("got panic %#v, not an *assert.AssertionError", p)
+	}
+	if err.Message != "balance: got 10, not 20" {
+		t.Errorf("got message %q, not %q", err.Message, "balance: got 10, not 20")
+	}
+	if err.Got != 10 || err.Want != 20 {
+		t.Errorf("got values %#v and %#v, not 10 and 20", err.Got, err.Want)
+	}
+}
+
+func TestAssertionErrorFromReusableAssertionHasNoComparedValues(t *testing.T) {
+	// given
+	// when
+	p := catchPanic(func() { assert.UsingPanic().That(theval.Equal(10, 20)) })
+
+	// then
+	err, _ := p.(*assert.AssertionError)
+	if err == nil {
+		t.Fatalf("got panic %#v, not an *assert.AssertionError", p)
+	}
+	if err.Message != "got 10, not 20" {
+		t.Errorf("got message %q, not %q", err.Message, "got 10, not 20")
+	}
+	if err.Got != nil || err.Want != nil {
+		t.Errorf("got values %#v and %#v, not nil", err.Got, err.Want)
+	}
+}
+
+func TestAssertionErrorUnwrapsToTheReportedError(t *testing.T) {
+	// given
+	err := fmt.Errorf("reading config: %w", io.ErrUnexpectedEOF)
+
+	// when
+	p := catchPanic(func() { assert.UsingPanic().That(err == nil, "unexpected error: %v", err) })
+
+	// then
+	failure, _ := p.(error)
+	if !errors.Is(failure, io.ErrUnexpectedEOF) {
+		t.Errorf("got panic %#v, which is not io.ErrUnexpectedEOF", p)
+	}
+}
@@ -0,0 +1,88 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package caller finds the stack frames of failed assertions.
+package caller
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Frame returns the stack frame where the failed assertion was made.
+func Frame() (runtime.Frame, bool) {
+	frames := Frames()
+	if len(frames) == 0 {
+		return runtime.Frame{}, false
+	}
+	return frames[0], true
+}
+
+// Frames returns the stack frames from the one where the failed assertion was made outwards.
+//
+// That is the first caller of Asserter.fail outside this library,
+// so any Middleware between it and the ErrorFunc is skipped.
+// When an ErrorFunc is called directly, it is its first caller outside this library.
+func Frames() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var all []runtime.Frame
+	for more := true; more; {
+		var frame runtime.Frame
+		frame, more = frames.Next()
+		all = append(all, frame)
+	}
+
+	start := -1
+	for i, frame := range all {
+		if frame.Function == failFunction {
+			start = i
+			break
+		}
+	}
+	for i := start + 1; i < len(all); i++ {
+		if !inLibrary(all[i].Function) {
+			return all[i:]
+		}
+	}
+	return nil
+}
+
+const failFunction = "github.com/szabba/assert/v2.Asserter.fail"
+
+// libraryPackages are the packages whose frames are skipped, since they only pass failures on.
+var libraryPackages = []string{
+	"github.com/szabba/assert/v2",
+	"github.com/szabba/assert/v2/asserttest",
+	"github.com/szabba/assert/v2/internal/caller",
+}
+
+func inLibrary(function string) bool {
+	for _, pkg := range libraryPackages {
+		if strings.HasPrefix(function, pkg+".") {
+			return true
+		}
+	}
+	return false
+}
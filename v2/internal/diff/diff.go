@@ -0,0 +1,204 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package diff produces line-based diffs of text for failure messages.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Context is the number of unchanged lines shown around each change.
+const Context = 3
+
+// MaxTable limits the size of the table used to find the longest common subsequence of the changed lines.
+// Texts whose changed lines would need a bigger table are not diffed line by line.
+const MaxTable = 1 << 20
+
+type opKind byte
+
+const (
+	same opKind = ' '
+	del  opKind = '-'
+	ins  opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+	// Positions of the line in the old and new text, counting from zero.
+	oldPos, newPos int
+}
+
+// Unified returns a unified diff turning oldText into newText.
+//
+// The names label the texts in the diff header.
+// When the texts are equal, Unified returns an empty string.
+// When too many lines changed, it only tells the line where the texts start to differ.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	a, c := splitLines(oldText), splitLines(newText)
+	prefix, suffix := common(a, c)
+	if (len(a)-prefix-suffix)*(len(c)-prefix-suffix) > MaxTable {
+		fmt.Fprintf(&b, "too many lines changed to diff, the texts differ from line %d\n", prefix+1)
+		return b.String()
+	}
+
+	ops := lineOps(a, c, prefix, suffix)
+	for _, h := range hunks(ops) {
+		writeHunk(&b, ops[h[0]:h[1]])
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// common returns the number of lines a and b share at their start and end.
+func common(a, b []string) (prefix, suffix int) {
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// lineOps computes an edit script using the longest common subsequence of lines.
+//
+// The lines between the common prefix and suffix are compared using a table with a cell for each pair of them.
+func lineOps(a, b []string, prefix, suffix int) []op {
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the LCS of midA[i:] and midB[j:].
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			switch {
+			case midA[i] == midB[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, op{same, a[i], i, i})
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, op{same, midA[i], prefix + i, prefix + j})
+			i, j = i+1, j+1
+		case j == len(midB) || (i < len(midA) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{del, midA[i], prefix + i, prefix + j})
+			i++
+		default:
+			ops = append(ops, op{ins, midB[j], prefix + i, prefix + j})
+			j++
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, op{same, a[len(a)-suffix+k], len(a) - suffix + k, len(b) - suffix + k})
+	}
+	return ops
+}
+
+// hunks returns the ranges of ops that should be shown, each as a pair of start and end indices.
+func hunks(ops []op) [][2]int {
+	var out [][2]int
+	for i, o := range ops {
+		if o.kind == same {
+			continue
+		}
+
+		start, end := i-Context, i+Context+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		if n := len(out); n > 0 && start <= out[n-1][1] {
+			out[n-1][1] = end
+			continue
+		}
+		out = append(out, [2]int{start, end})
+	}
+	return out
+}
+
+func writeHunk(b *strings.Builder, ops []op) {
+	oldLen, newLen := 0, 0
+	for _, o := range ops {
+		if o.kind != ins {
+			oldLen++
+		}
+		if o.kind != del {
+			newLen++
+		}
+	}
+
+	oldStart, newStart := ops[0].oldPos+1, ops[0].newPos+1
+	if oldLen == 0 {
+		oldStart--
+	}
+	if newLen == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLen, newStart, newLen)
+	for _, o := range ops {
+		b.WriteByte(byte(o.kind))
+		b.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diff_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/internal/diff"
+)
+
+func TestUnified(t *testing.T) {
+
+	cases := []struct {
+		Name     string
+		Old, New string
+		Diff     string
+	}{
+		{
+			Name: "Equal",
+			Old:  "a\nb\n",
+			New:  "a\nb\n",
+			Diff: "",
+		},
+		{
+			Name: "Changed",
+			Old:  "a\nb\nc\n",
+			New:  "a\nB\nc\n",
+			Diff: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			Name: "Inserted",
+			Old:  "",
+			New:  "a\n",
+			Diff: "--- old\n+++ new\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+		{
+			Name: "SeparateHunks",
+			Old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			New:  "0\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			Diff: "--- old\n+++ new\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,3 @@\n 7\n 8\n 9\n-10\n",
+		},
+		{
+			Name: "MissingNewline",
+			Old:  "a\n",
+			New:  "a",
+			Diff: "--- old\n+++ new\n@@ -1,1 +1,1 @@\n-a\n+a\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range cases {
+		tt := tt
+
+		t.Run(tt.Name, func(t *testing.T) {
+			// given
+			// when
+			got := diff.Unified("old", "new", tt.Old, tt.New)
+
+			// then
+			assert.Using(t.Errorf).That(theval.Equal(got, tt.Diff))
+		})
+	}
+
+}
+
+func TestUnifiedWithTooManyChanges(t *testing.T) {
+	// given
+	var old, new strings.Builder
+	old.WriteString("same\n")
+	new.WriteString("same\n")
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&old, "old %d\n", i)
+		fmt.Fprintf(&new, "new %d\n", i)
+	}
+
+	// when
+	got := diff.Unified("old", "new", old.String(), new.String())
+
+	// then
+	want := "--- old\n+++ new\ntoo many lines changed to diff, the texts differ from line 2\n"
+	assert.Using(t.Errorf).That(theval.Equal(got, want))
+}
@@ -0,0 +1,43 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package property checks properties for the theprop and thefuzz packages.
+package property
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Holds calls prop and returns its result.
+//
+// A prop that panics does not hold.
+// The message then holds the panic value, and stack the stack trace of the panicking goroutine.
+func Holds(prop func() (bool, string)) (ok bool, msg string, stack []byte) {
+	defer func() {
+		if p := recover(); p != nil {
+			ok, msg, stack = false, fmt.Sprintf("panic: %v", p), debug.Stack()
+		}
+	}()
+	ok, msg = prop()
+	return ok, msg, nil
+}
@@ -0,0 +1,57 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package property_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theval"
+
+	"github.com/szabba/assert/v2/internal/property"
+)
+
+func TestHolds(t *testing.T) {
+	// given
+	// when
+	ok, msg, stack := property.Holds(func() (bool, string) { return false, "got 1, not 2" })
+
+	// then
+	assert.Using(t.Errorf).
+		That(theval.Equal(ok, false)).
+		That(theval.Equal(msg, "got 1, not 2")).
+		That(stack == nil, "got stack %s", stack)
+}
+
+func TestHoldsRecoversPanics(t *testing.T) {
+	// given
+	// when
+	ok, msg, stack := property.Holds(func() (bool, string) { panic("boom") })
+
+	// then
+	assert.Using(t.Errorf).
+		That(theval.Equal(ok, false)).
+		That(theval.Equal(msg, "panic: boom")).
+		That(strings.Contains(string(stack), "property_test.TestHoldsRecoversPanics"), "got stack without the panicking function:\n%s", stack)
+}
@@ -0,0 +1,59 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package update decides whether tests should update their expectations instead of checking them.
+//
+// Importing it defines the -update flag, as described in the documentation of the thegolden package.
+package update
+
+import (
+	"flag"
+	"os"
+	"strconv"
+)
+
+var updateFlag = lookupOrDefine(flag.CommandLine)
+
+// lookupOrDefine returns the -update flag of fs, defining it if it does not exist yet.
+func lookupOrDefine(fs *flag.FlagSet) flag.Value {
+	if f := fs.Lookup("update"); f != nil {
+		return f.Value
+	}
+	fs.Bool("update", false, "update golden files and snapshots instead of comparing to them")
+	return fs.Lookup("update").Value
+}
+
+// Enabled returns true when the tests were run with the -update flag,
+// or when the environment variable env is set to a non-empty value.
+func Enabled(env string) bool {
+	return isSet(updateFlag) || os.Getenv(env) != ""
+}
+
+func isSet(v flag.Value) bool {
+	if g, ok := v.(flag.Getter); ok {
+		if b, ok := g.Get().(bool); ok {
+			return b
+		}
+	}
+	b, _ := strconv.ParseBool(v.String())
+	return b
+}
@@ -0,0 +1,81 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestLookupOrDefineDefinesTheFlag(t *testing.T) {
+	// given
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	// when
+	v := lookupOrDefine(fs)
+	err := fs.Parse([]string{"-update"})
+
+	// then
+	if err != nil {
+		t.Fatalf("got error %v parsing -update", err)
+	}
+	if !isSet(v) {
+		t.Error("the flag is not set")
+	}
+}
+
+func TestLookupOrDefineReusesAnExistingFlag(t *testing.T) {
+	// given
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	existing := fs.Bool("update", false, "update the fixtures")
+
+	// when
+	v := lookupOrDefine(fs)
+	err := fs.Parse([]string{"-update"})
+
+	// then
+	if err != nil {
+		t.Fatalf("got error %v parsing -update", err)
+	}
+	if !*existing || !isSet(v) {
+		t.Errorf("got existing flag %v and returned flag %v, not both set", *existing, isSet(v))
+	}
+}
+
+func TestIsSetAcceptsNonBoolFlags(t *testing.T) {
+	// given
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("update", "", "update the fixtures")
+
+	// when
+	v := lookupOrDefine(fs)
+	err := fs.Parse([]string{"-update=true"})
+
+	// then
+	if err != nil {
+		t.Fatalf("got error %v parsing -update", err)
+	}
+	if !isSet(v) {
+		t.Error("the flag is not set")
+	}
+}
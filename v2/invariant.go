@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// An InvariantMode describes how failed invariants are reported.
+type InvariantMode int32
+
+const (
+	// InvariantPanic makes failed invariants panic, just like UsingPanic.
+	InvariantPanic InvariantMode = iota
+
+	// InvariantLog makes failed invariants get logged using log.Printf and counted.
+	InvariantLog
+)
+
+var (
+	invariantMode     atomic.Int32
+	invariantFailures atomic.Int64
+)
+
+// SetInvariantMode sets how failed invariants are reported and returns the previous mode.
+//
+// It is safe to call concurrently with checking invariants.
+func SetInvariantMode(mode InvariantMode) InvariantMode {
+	return InvariantMode(invariantMode.Swap(int32(mode)))
+}
+
+// InvariantFailures returns the number of failed invariants that were logged rather than panicked on.
+func InvariantFailures() int64 {
+	return invariantFailures.Load()
+}
+
+// Invariant asserts that cond is true in production code.
+//
+// Invariants are checked only when the program is built with the assert_debug build tag.
+// Otherwise Invariant does nothing.
+// Note that the arguments are still evaluated, use InvariantFunc or InvariantsEnabled when that is expensive.
+//
+// How a failure gets reported depends on the InvariantMode.
+func Invariant(cond bool, msgFmt string, args ...any) {
+	if !InvariantsEnabled {
+		return
+	}
+	invariantAsserter().That(cond, msgFmt, args...)
+}
+
+// InvariantFunc is like Invariant, but check is only called when invariants are enabled.
+//
+//	assert.InvariantFunc(func() (bool, string) { return theslice.Equal(index.Keys(), store.Keys()) })
+func InvariantFunc(check func() (bool, string)) {
+	if !InvariantsEnabled {
+		return
+	}
+	invariantAsserter().ThatFunc(check)
+}
+
+func invariantAsserter() Asserter {
+	if InvariantMode(invariantMode.Load()) == InvariantLog {
+		return Using(logInvariant)
+	}
+	return UsingPanic()
+}
+
+func logInvariant(msgFmt string, args ...any) {
+	invariantFailures.Add(1)
+	log.Printf("invariant violated: %s", fmt.Sprintf(msgFmt, args...))
+}
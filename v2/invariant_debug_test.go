@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build assert_debug
+
+package assert_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+func TestFailingInvariantPanicsByDefault(t *testing.T) {
+	// given
+	// when
+	p := catchPanic(func() { assert.Invariant(false, "Oops: %#v", false) })
+
+	// then
+	wantMsg := "Oops: false"
+	if msg := panicMessage(p); msg != wantMsg {
+		t.Errorf("got panic message %q, not %q", msg, wantMsg)
+	}
+}
+
+func TestFailingInvariantFuncPanicsByDefault(t *testing.T) {
+	// given
+	// when
+	p := catchPanic(func() { assert.InvariantFunc(func() (bool, string) { return false, "100% wrong" }) })
+
+	// then
+	wantMsg := "100% wrong"
+	if msg := panicMessage(p); msg != wantMsg {
+		t.Errorf("got panic message %q, not %q", msg, wantMsg)
+	}
+}
+
+func TestFailingInvariantIsLoggedAndCountedInLogMode(t *testing.T) {
+	// given
+	defer assert.SetInvariantMode(assert.SetInvariantMode(assert.InvariantLog))
+
+	defer log.SetFlags(log.Flags())
+	defer log.SetOutput(os.Stderr)
+
+	var out bytes.Buffer
+	log.SetFlags(0)
+	log.SetOutput(&out)
+
+	before := assert.InvariantFailures()
+
+	// when
+	p := catchPanic(func() {
+		assert.Invariant(true, "OK")
+		assert.Invariant(false, "Oops: %#v", false)
+	})
+
+	// then
+	if p != nil {
+		t.Errorf("unexpected panic: %#v", p)
+	}
+	if got := assert.InvariantFailures() - before; got != 1 {
+		t.Errorf("got %d failures counted, not 1", got)
+	}
+	if got, want := out.String(), "invariant violated: Oops: false\n"; got != want {
+		t.Errorf("got log output %q, not %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+// ThatFunc asserts that check returns true.
+//
+// It works like That(check()), but the message returned by check is never treated as a format string.
+// Since check does not escape, passing assertions made with ThatFunc do not allocate even when check is a closure,
+// as long as check itself only builds its message on failure, like the reusable [assertions] do.
+//
+//	a.ThatFunc(func() (bool, string) { return theval.Equal(got, want) })
+//
+// [assertions]: https://pkg.go.dev/github.com/szabba/assert/v2/assertions
+func (a Asserter) ThatFunc(check func() (bool, string)) Asserter {
+	if ok, msg := check(); a.report(ok) {
+		a.fail("%s", []any{msg})
+	}
+	return a
+}
+
+// Lazy is a message argument that is only computed when it gets formatted.
+//
+// Use it to avoid building expensive descriptions for assertions that pass:
+//
+//	a.That(tree.Balanced(), "tree is not balanced:\n%s", assert.Lazy(tree.Dump))
+//
+// Lazy implements fmt.Stringer, so it is formatted with the %s and %v verbs.
+//
+// Lazy saves the work of building the description, but not all the allocations of passing it.
+// Like any argument to That, the Lazy and the closure it wraps escape to the heap, even when the assertion passes.
+// On hot paths, use ThatFunc and build the message inside check instead.
+type Lazy func() string
+
+// String calls l.
+func (l Lazy) String() string {
+	return l()
+}
@@ -0,0 +1,169 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+	"github.com/szabba/assert/v2/assertions/theval"
+)
+
+func TestThatFuncPasses(t *testing.T) {
+	// given
+	called := false
+	errFunc := func(_ string, _ ...any) { called = true }
+
+	// when
+	assert.Using(errFunc).ThatFunc(func() (bool, string) { return true, "OK" })
+
+	// then
+	if called {
+		t.Error("the ErrorFunc was called")
+	}
+}
+
+func TestThatFuncFailsWithMessageFromCheck(t *testing.T) {
+	// given
+	var got string
+	errFunc := func(msgFmt string, args ...any) { got = fmt.Sprintf(msgFmt, args...) }
+
+	// when
+	assert.Using(errFunc).ThatFunc(func() (bool, string) { return false, "100% wrong" })
+
+	// then
+	if want := "100% wrong"; got != want {
+		t.Errorf("got message %q, not %q", got, want)
+	}
+}
+
+func TestLazyIsOnlyComputedOnFailure(t *testing.T) {
+	// given
+	calls := 0
+	describe := assert.Lazy(func() string {
+		calls++
+		return "details"
+	})
+
+	var got string
+	errFunc := func(msgFmt string, args ...any) { got = fmt.Sprintf(msgFmt, args...) }
+
+	// when
+	assert.Using(errFunc).
+		That(true, "passed: %s", describe).
+		That(false, "failed: %s", describe)
+
+	// then
+	if calls != 1 {
+		t.Errorf("got %d calls to the lazy message, not 1", calls)
+	}
+	if want := "failed: details"; got != want {
+		t.Errorf("got message %q, not %q", got, want)
+	}
+}
+
+func TestPassingAssertionsDoNotAllocate(t *testing.T) {
+	a := assert.Using(t.Errorf)
+	got, want := 1000, 1000
+
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"That", func() { a.That(got == want, "not equal") }},
+		{"Reusable", func() { a.That(theval.Equal(got, want)) }},
+		{"ThatFunc", func() { a.ThatFunc(func() (bool, string) { return theval.Equal(got, want) }) }},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			allocs := testing.AllocsPerRun(100, tt.f)
+
+			// then
+			if allocs != 0 {
+				t.Errorf("got %v allocations per run, not 0", allocs)
+			}
+		})
+	}
+}
+
+func BenchmarkThat(b *testing.B) {
+	a := assert.Using(b.Errorf)
+	got, want := 1000, 1000
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.That(got == want, "not equal")
+	}
+}
+
+func BenchmarkThatWithArgs(b *testing.B) {
+	a := assert.Using(b.Errorf)
+	got, want := 1000, 1000
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.That(got == want, "got %d, not %d", got, want)
+	}
+}
+
+func BenchmarkThatReusable(b *testing.B) {
+	a := assert.Using(b.Errorf)
+	got, want := 1000, 1000
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.That(theval.Equal(got, want))
+	}
+}
+
+func BenchmarkThatFunc(b *testing.B) {
+	a := assert.Using(b.Errorf)
+	got, want := 1000, 1000
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.ThatFunc(func() (bool, string) { return theval.Equal(got, want) })
+	}
+}
+
+func BenchmarkThatEagerMessage(b *testing.B) {
+	a := assert.Using(b.Errorf)
+	s := make([]int, 100)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.That(len(s) == 100, "%s", fmt.Sprint(s))
+	}
+}
+
+func BenchmarkThatLazyMessage(b *testing.B) {
+	a := assert.Using(b.Errorf)
+	s := make([]int, 100)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.That(len(s) == 100, "%s", assert.Lazy(func() string { return fmt.Sprint(s) }))
+	}
+}
@@ -0,0 +1,181 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Metrics counts how often the assertions made by some asserters are evaluated and how often they fail.
+//
+// The counts are kept per assertion site.
+// A site is the file and line where an assertion is made, unless the asserter was given a site name using Named.
+//
+// Metrics implements expvar.Var, so they can be exposed using
+//
+//	expvar.Publish("assertions", metrics)
+//
+// A Metrics is safe for concurrent use.
+// It must be created using NewMetrics.
+type Metrics struct {
+	mu       sync.Mutex
+	interval time.Duration
+	byPC     map[uintptr]*siteMetrics
+	byName   map[string]*siteMetrics
+}
+
+// SiteStats are the counts for a single assertion site.
+type SiteStats struct {
+	// Evaluations is the number of times the assertions at the site were evaluated.
+	Evaluations int64
+
+	// Failures is the number of times the assertions at the site failed, including the suppressed ones.
+	Failures int64
+
+	// Suppressed is the number of failures that were not reported because of rate limiting.
+	Suppressed int64
+}
+
+type siteMetrics struct {
+	SiteStats
+	lastReport time.Time
+}
+
+// NewMetrics creates empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		byPC:   map[uintptr]*siteMetrics{},
+		byName: map[string]*siteMetrics{},
+	}
+}
+
+// LimitReports makes failures at a site get reported at most once every interval.
+//
+// The failures that are not reported are still counted.
+// An interval of zero, which is the default, turns rate limiting off.
+func (m *Metrics) LimitReports(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.interval = interval
+}
+
+// WithMetrics creates an Asserter that reports failures like a, and counts the assertions it makes in m.
+func (a Asserter) WithMetrics(m *Metrics) Asserter {
+	a.metrics = m
+	return a
+}
+
+// Named creates an Asserter that reports failures like a, but counts all the assertions it makes under a single site name.
+//
+// It only makes a difference for asserters with metrics.
+func (a Asserter) Named(site string) Asserter {
+	a.site = site
+	return a
+}
+
+// Snapshot returns the current counts for all the sites where assertions were made.
+//
+// The sites are keyed by their names or file:line locations.
+func (m *Metrics) Snapshot() map[string]SiteStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snap := make(map[string]SiteStats, len(m.byPC)+len(m.byName))
+	add := func(key string, s SiteStats) {
+		prev := snap[key]
+		snap[key] = SiteStats{
+			Evaluations: prev.Evaluations + s.Evaluations,
+			Failures:    prev.Failures + s.Failures,
+			Suppressed:  prev.Suppressed + s.Suppressed,
+		}
+	}
+
+	for pc, s := range m.byPC {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		add(fmt.Sprintf("%s:%d", frame.File, frame.Line), s.SiteStats)
+	}
+	for name, s := range m.byName {
+		add(name, s.SiteStats)
+	}
+	return snap
+}
+
+// String returns the snapshot of the metrics as JSON.
+func (m *Metrics) String() string {
+	out, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(out)
+}
+
+// record counts an assertion made at a site and tells whether its failure should be reported.
+func (m *Metrics) record(name string, pc uintptr, ok bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.site(name, pc)
+	s.Evaluations++
+	if ok {
+		return false
+	}
+
+	s.Failures++
+	if m.interval > 0 {
+		now := time.Now()
+		if !s.lastReport.IsZero() && now.Sub(s.lastReport) < m.interval {
+			s.Suppressed++
+			return false
+		}
+		s.lastReport = now
+	}
+	return true
+}
+
+func (m *Metrics) site(name string, pc uintptr) *siteMetrics {
+	if name == "" {
+		return siteIn(m.byPC, pc)
+	}
+	return siteIn(m.byName, name)
+}
+
+func siteIn[K comparable](sites map[K]*siteMetrics, key K) *siteMetrics {
+	s, ok := sites[key]
+	if !ok {
+		s = &siteMetrics{}
+		sites[key] = s
+	}
+	return s
+}
+
+// callerPC returns the program counter of the call to the Asserter method that called report.
+func callerPC() uintptr {
+	var pcs [1]uintptr
+	// Skip runtime.Callers, callerPC, Asserter.report and the Asserter method itself.
+	runtime.Callers(4, pcs[:])
+	return pcs[0]
+}
@@ -0,0 +1,114 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/szabba/assert/v2"
+)
+
+func TestMetricsCountEvaluationsAndFailuresPerSite(t *testing.T) {
+	// given
+	m := assert.NewMetrics()
+	a := assert.Using(func(string, ...any) {}).WithMetrics(m)
+
+	// when
+	_, file, line, _ := runtime.Caller(0)
+	for i := 0; i < 3; i++ {
+		a.That(i < 2, "too big")
+		a.ThatFunc(func() (bool, string) { return true, "" })
+	}
+
+	// then
+	want := map[string]assert.SiteStats{
+		fmt.Sprintf("%s:%d", file, line+2): {Evaluations: 3, Failures: 1},
+		fmt.Sprintf("%s:%d", file, line+3): {Evaluations: 3},
+	}
+	if got := m.Snapshot(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got snapshot %v, not %v", got, want)
+	}
+}
+
+func TestMetricsCountNamedSitesTogether(t *testing.T) {
+	// given
+	m := assert.NewMetrics()
+	a := assert.Using(func(string, ...any) {}).WithMetrics(m).Named("queue capacity")
+
+	// when
+	a.That(true, "OK")
+	a.That(false, "Oops")
+
+	// then
+	want := map[string]assert.SiteStats{"queue capacity": {Evaluations: 2, Failures: 1}}
+	if got := m.Snapshot(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got snapshot %v, not %v", got, want)
+	}
+}
+
+func TestMetricsLimitReports(t *testing.T) {
+	// given
+	m := assert.NewMetrics()
+	m.LimitReports(time.Hour)
+
+	reports := 0
+	a := assert.Using(func(string, ...any) { reports++ }).WithMetrics(m).Named("site")
+
+	// when
+	for i := 0; i < 3; i++ {
+		a.That(false, "Oops")
+	}
+
+	// then
+	if reports != 1 {
+		t.Errorf("got %d failures reported, not 1", reports)
+	}
+	want := map[string]assert.SiteStats{"site": {Evaluations: 3, Failures: 3, Suppressed: 2}}
+	if got := m.Snapshot(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got snapshot %v, not %v", got, want)
+	}
+}
+
+func TestMetricsArePublishedAsJSON(t *testing.T) {
+	// given
+	m := assert.NewMetrics()
+	// The global expvar registry is left alone, since publishing a name twice panics with -count=2.
+	var v expvar.Var = m
+
+	assert.Using(func(string, ...any) {}).WithMetrics(m).Named("site").That(false, "Oops")
+
+	// when
+	var got map[string]assert.SiteStats
+	err := json.Unmarshal([]byte(v.String()), &got)
+
+	// then
+	want := map[string]assert.SiteStats{"site": {Evaluations: 1, Failures: 1}}
+	if err != nil || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got published metrics %v (error %v), not %v", got, err, want)
+	}
+}
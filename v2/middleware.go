@@ -0,0 +1,120 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert
+
+import (
+	"sync"
+
+	"github.com/szabba/assert/v2/internal/caller"
+)
+
+// A Middleware wraps an ErrorFunc, changing how failures get reported.
+//
+// It gets the ErrorFunc it wraps as next and should usually call it, maybe with a changed message.
+// A Middleware that does not call next stops the failure from being reported any further.
+type Middleware func(next ErrorFunc) ErrorFunc
+
+// Use returns an Asserter that reports failures through the middleware.
+//
+// The first middleware is the outermost one: it sees failures first and calls the next one.
+// The last one calls the error func of the asserter.
+// When the asserter panics to report failures, the panic happens after all the middleware ran.
+//
+//	a := assert.Using(t.Errorf).Use(assert.WithStack)
+func (a Asserter) Use(mws ...Middleware) Asserter {
+	onErr := a.errorFunc()
+	for i := len(mws) - 1; i >= 0; i-- {
+		onErr = mws[i](onErr)
+	}
+	a.onErr = onErr
+	return a
+}
+
+// errorFunc returns the error func of the asserter, which panics when the asserter got none.
+func (a Asserter) errorFunc() ErrorFunc {
+	if a.onErr == nil {
+		return panicking
+	}
+	return a.onErr
+}
+
+// A hook is a Middleware used by all asserters.
+//
+// It is a pointer, so it can be told apart from other hooks using the same middleware.
+type hook struct {
+	mw Middleware
+}
+
+var hooks struct {
+	sync.RWMutex
+	list []*hook
+}
+
+// Hook adds a middleware that all asserters report failures through.
+//
+// Hooks wrap the error funcs of asserters after any middleware added with Use,
+// so they see failures before it does.
+// The first hook added is the outermost one.
+//
+// Hook returns a function that removes the hook.
+// In tests, pass it to t.Cleanup:
+//
+//	t.Cleanup(assert.Hook(countFailures))
+func Hook(mw Middleware) (unhook func()) {
+	h := &hook{mw: mw}
+
+	hooks.Lock()
+	defer hooks.Unlock()
+	hooks.list = append(hooks.list, h)
+
+	return func() {
+		hooks.Lock()
+		defer hooks.Unlock()
+		for i, other := range hooks.list {
+			if other == h {
+				hooks.list = append(hooks.list[:i:i], hooks.list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// hooked wraps onErr in the hooks.
+func hooked(onErr ErrorFunc) ErrorFunc {
+	hooks.RLock()
+	defer hooks.RUnlock()
+	for i := len(hooks.list) - 1; i >= 0; i-- {
+		onErr = hooks.list[i].mw(onErr)
+	}
+	return onErr
+}
+
+// WithStack is a Middleware that adds the stack trace of the failed assertion to the message.
+//
+// The trace starts where the assertion was made, not in the middleware or the error func.
+func WithStack(next ErrorFunc) ErrorFunc {
+	return func(msgFmt string, args ...any) {
+		stack := (&AssertionError{Stack: caller.Frames()}).StackTrace()
+		next(msgFmt+"\n\nassertion stack:\n%s", append(args[:len(args):len(args)], stack)...)
+	}
+}
@@ -0,0 +1,154 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package assert_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+func TestUseRunsMiddlewareInOrder(t *testing.T) {
+	// given
+	var calls []string
+	errFunc := func(msgFmt string, args ...any) {
+		calls = append(calls, fmt.Sprintf(msgFmt, args...))
+	}
+
+	// when
+	assert.Using(errFunc).
+		Use(prefix("outer"), prefix("inner")).
+		That(false, "failed %d", 1)
+
+	// then
+	// Each middleware prefixes the message it passes on, so the innermost prefix comes first.
+	want := "inner: outer: failed 1"
+	if len(calls) != 1 || calls[0] != want {
+		t.Errorf("got calls %q, not [%q]", calls, want)
+	}
+}
+
+func TestUseDoesNotCallMiddlewareForPassingAssertions(t *testing.T) {
+	// given
+	called := false
+	mw := func(next assert.ErrorFunc) assert.ErrorFunc {
+		return func(msgFmt string, args ...any) { called = true }
+	}
+
+	// when
+	assert.UsingPanic().Use(mw).That(true, "OK")
+
+	// then
+	if called {
+		t.Error("the middleware was called")
+	}
+}
+
+func TestUseWithPanicPanicsAfterMiddleware(t *testing.T) {
+	// given
+	a := assert.UsingPanic().Use(prefix("wrapped"))
+
+	// when
+	p := catchPanic(func() { a.That(false, "Oops: %#v", false) })
+
+	// then
+	wantMsg := "wrapped: Oops: false"
+	if msg := panicMessage(p); msg != wantMsg {
+		t.Errorf("got panic message %q, not %q", msg, wantMsg)
+	}
+}
+
+func TestMiddlewareCanStopFailures(t *testing.T) {
+	// given
+	called := false
+	errFunc := func(string, ...any) { called = true }
+	drop := func(next assert.ErrorFunc) assert.ErrorFunc {
+		return func(string, ...any) {}
+	}
+
+	// when
+	assert.Using(errFunc).Use(drop).That(false, "Oops")
+
+	// then
+	if called {
+		t.Error("the ErrorFunc was called")
+	}
+}
+
+func TestHookWrapsAllAsserters(t *testing.T) {
+	// given
+	var calls []string
+	errFunc := func(msgFmt string, args ...any) {
+		calls = append(calls, fmt.Sprintf(msgFmt, args...))
+	}
+
+	unhookFirst := assert.Hook(prefix("first"))
+	defer unhookFirst()
+	unhookSecond := assert.Hook(prefix("second"))
+	defer unhookSecond()
+
+	// when
+	assert.Using(errFunc).That(false, "plain")
+	assert.Using(errFunc).Use(prefix("used")).That(false, "with middleware")
+	unhookFirst()
+	assert.Using(errFunc).That(false, "unhooked")
+
+	// then
+	want := []string{
+		"second: first: plain",
+		"used: second: first: with middleware",
+		"second: unhooked",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("got calls %q, not %q", calls, want)
+	}
+}
+
+func TestWithStackStartsAtTheAssertion(t *testing.T) {
+	// given
+	var msg string
+	errFunc := func(msgFmt string, args ...any) { msg = fmt.Sprintf(msgFmt, args...) }
+
+	// when
+	_, file, line, _ := runtime.Caller(0)
+	assert.Using(errFunc).Use(assert.WithStack, prefix("after")).That(false, "Oops")
+
+	// then
+	wantPrefix := fmt.Sprintf(
+		"after: Oops\n\nassertion stack:\ngithub.com/szabba/assert/v2_test.TestWithStackStartsAtTheAssertion\n\t%s:%d\n",
+		file, line+1)
+	if !strings.HasPrefix(msg, wantPrefix) {
+		t.Errorf("got message %q, want it to start with %q", msg, wantPrefix)
+	}
+}
+
+func prefix(p string) assert.Middleware {
+	return func(next assert.ErrorFunc) assert.ErrorFunc {
+		return func(msgFmt string, args ...any) {
+			next(p+": "+msgFmt, args...)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.21
+
+package assert
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/szabba/assert/v2/internal/caller"
+)
+
+// Slog creates an ErrorFunc that logs failures as slog records at the given level.
+//
+// The attrs are added to every record, which makes them a good place for the assertion name and labels:
+//
+//	a := assert.Using(assert.Slog(logger, slog.LevelError, slog.String("assertion", "queue capacity")))
+//
+// Arguments of type slog.Attr are not used to format the message.
+// They are added to the record instead, so that values like got and want can be logged in a structured way:
+//
+//	a.That(got == want, "balance mismatch", slog.Int("got", got), slog.Int("want", want))
+//
+// Each record also has a caller attribute with the file:line where the assertion was made.
+// When logger is nil, slog.Default() is used.
+func Slog(logger *slog.Logger, level slog.Level, attrs ...slog.Attr) ErrorFunc {
+	return func(msgFmt string, args ...any) {
+		logFailure(logger, level, attrs, msgFmt, args)
+	}
+}
+
+// SlogPanic is like Slog, but after logging a failure it panics with an *AssertionError, like UsingPanic.
+func SlogPanic(logger *slog.Logger, level slog.Level, attrs ...slog.Attr) ErrorFunc {
+	return func(msgFmt string, args ...any) {
+		panic(newAssertionError(logFailure(logger, level, attrs, msgFmt, args), msgFmt, args))
+	}
+}
+
+func logFailure(logger *slog.Logger, level slog.Level, attrs []slog.Attr, msgFmt string, args []any) string {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	var fmtArgs []any
+	var argAttrs []slog.Attr
+	for _, arg := range args {
+		if attr, ok := arg.(slog.Attr); ok {
+			argAttrs = append(argAttrs, attr)
+		} else {
+			fmtArgs = append(fmtArgs, arg)
+		}
+	}
+	msg := fmt.Sprintf(msgFmt, fmtArgs...)
+
+	ctx := context.Background()
+	if !logger.Enabled(ctx, level) {
+		return msg
+	}
+
+	frame, found := caller.Frame()
+	r := slog.NewRecord(time.Now(), level, msg, frame.PC)
+	r.AddAttrs(attrs...)
+	r.AddAttrs(argAttrs...)
+	if found {
+		r.AddAttrs(slog.String("caller", fmt.Sprintf("%s:%d", frame.File, frame.Line)))
+	}
+	_ = logger.Handler().Handle(ctx, r)
+
+	return msg
+}
@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2023 Karol Marcjan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.21
+
+package assert_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"testing"
+
+	"github.com/szabba/assert/v2"
+)
+
+func TestSlogLogsFailureWithAttributes(t *testing.T) {
+	// given
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	a := assert.Using(assert.Slog(logger, slog.LevelWarn, slog.String("assertion", "balance")))
+
+	// when
+	_, file, line, _ := runtime.Caller(0)
+	a.That(1 == 2, "balance of %s mismatch", "alice", slog.Int("got", 1), slog.Int("want", 2))
+
+	// then
+	want := fmt.Sprintf("level=WARN msg=\"balance of alice mismatch\" assertion=balance got=1 want=2 caller=%s:%d\n", file, line+1)
+	if got := out.String(); got != want {
+		t.Errorf("got log output %q, not %q", got, want)
+	}
+}
+
+func TestSlogDoesNotLogPassingAssertions(t *testing.T) {
+	// given
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+
+	// when
+	assert.Using(assert.Slog(logger, slog.LevelError)).That(true, "OK")
+
+	// then
+	if got := out.String(); got != "" {
+		t.Errorf("got log output %q, not nothing", got)
+	}
+}
+
+func TestSlogPanicLogsAndPanics(t *testing.T) {
+	// given
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+
+	// when
+	p := catchPanic(func() {
+		assert.Using(assert.SlogPanic(logger, slog.LevelError)).That(false, "Oops: %#v", false, slog.Bool("fatal", true))
+	})
+
+	// then
+	if msg, want := panicMessage(p), "Oops: false"; msg != want {
+		t.Errorf("got panic message %q, not %q", msg, want)
+	}
+	if out.Len() == 0 {
+		t.Error("nothing was logged")
+	}
+}